@@ -1,12 +1,11 @@
 package canopy
 
 import (
-	"cmp"
 	"fmt"
 )
 
 // Tree The interface for all tree types.
-type Tree[E cmp.Ordered] interface {
+type Tree[E any] interface {
 	// Insert Places a value into the tree.
 	// Returns true if the value was inserted, false if the value exists already.
 	Insert(value E) bool
@@ -20,32 +19,86 @@ type Tree[E cmp.Ordered] interface {
 
 	// Traverse provides a way to visit the nodes in a binary tree.
 	Traverse(method func(node Node[E], v func(node Node[E]) bool) bool, visitor func(node Node[E]) bool)
+
+	// Len Returns the number of values in the tree.
+	Len() int
+
+	// IsEmpty Returns true if the tree holds no values.
+	IsEmpty() bool
+
+	// Clear Removes every value from the tree.
+	Clear()
 }
 
-// Node is a common interface for all binary tree nodes.
-type Node[E cmp.Ordered] interface {
+// Node is a common interface for all binary tree nodes.  Nodes are read-only, the tree they belong to
+// must not be modified while a Node is being inspected.
+type Node[E any] interface {
 	Value() E
-	p() (Node[E], bool)
-	l() (Node[E], bool)
-	r() (Node[E], bool)
+
+	// Parent Returns the parent of the node, and false for the root.
+	Parent() (Node[E], bool)
+
+	// Left Returns the left child of the node, and false if there isn't one.
+	Left() (Node[E], bool)
+
+	// Right Returns the right child of the node, and false if there isn't one.
+	Right() (Node[E], bool)
+
+	// IsLeaf Returns true if the node has no children.
+	IsLeaf() bool
+}
+
+// searchTree is implemented by every tree type, so the queries they share can walk it.
+type searchTree[E any] interface {
+	rootNode() (Node[E], bool)
+	comparator() func(a, b E) int
+	removeValue(value E) bool
+}
+
+// sizedNode is implemented by the nodes of every tree in this package, which keep the size of their subtree.
+type sizedNode[E any] interface {
+	Node[E]
+	count() int
+}
+
+// Returns the number of nodes in the subtree below n, counting them when n doesn't keep its subtree size.
+func subtreeSize[E any](n Node[E]) int {
+	if sn, ok := n.(sizedNode[E]); ok {
+		return sn.count()
+	}
+
+	size := 0
+	stack := []Node[E]{n}
+	for len(stack) > 0 {
+		n = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		size++
+		if left, ok := n.Left(); ok {
+			stack = append(stack, left)
+		}
+		if right, ok := n.Right(); ok {
+			stack = append(stack, right)
+		}
+	}
+	return size
 }
 
 // InsertAll Inserts a slice of values into a given tree.
-func InsertAll[E cmp.Ordered](t Tree[E], values ...E) {
+func InsertAll[E any](t Tree[E], values ...E) {
 	for _, v := range values {
 		t.Insert(v)
 	}
 }
 
 // PostOrder recursively traverses a binary tree in post order.
-func PostOrder[E cmp.Ordered](node Node[E], v func(node Node[E]) bool) bool {
-	if left, ok := node.l(); ok {
+func PostOrder[E any](node Node[E], v func(node Node[E]) bool) bool {
+	if left, ok := node.Left(); ok {
 		if !PostOrder(left, v) {
 			return false
 		}
 	}
 
-	if right, ok := node.r(); ok {
+	if right, ok := node.Right(); ok {
 		if !PostOrder(right, v) {
 			return false
 		}
@@ -55,9 +108,9 @@ func PostOrder[E cmp.Ordered](node Node[E], v func(node Node[E]) bool) bool {
 }
 
 // InOrder recursively traverses a binary tree "in order".
-func InOrder[E cmp.Ordered](node Node[E], v func(node Node[E]) bool) bool {
+func InOrder[E any](node Node[E], v func(node Node[E]) bool) bool {
 
-	if left, ok := node.l(); ok {
+	if left, ok := node.Left(); ok {
 		if !InOrder(left, v) {
 			return false
 		}
@@ -67,7 +120,7 @@ func InOrder[E cmp.Ordered](node Node[E], v func(node Node[E]) bool) bool {
 		return false
 	}
 
-	if right, ok := node.r(); ok {
+	if right, ok := node.Right(); ok {
 		if !InOrder(right, v) {
 			return false
 		}
@@ -77,19 +130,19 @@ func InOrder[E cmp.Ordered](node Node[E], v func(node Node[E]) bool) bool {
 }
 
 // PreOrder recursively traverses a binary tree with "pre order".
-func PreOrder[E cmp.Ordered](node Node[E], v func(node Node[E]) bool) bool {
+func PreOrder[E any](node Node[E], v func(node Node[E]) bool) bool {
 
 	if !v(node) {
 		return false
 	}
 
-	if left, ok := node.l(); ok {
+	if left, ok := node.Left(); ok {
 		if !PreOrder(left, v) {
 			return false
 		}
 	}
 
-	if right, ok := node.r(); ok {
+	if right, ok := node.Right(); ok {
 		if !PreOrder(right, v) {
 			return false
 		}
@@ -98,8 +151,68 @@ func PreOrder[E cmp.Ordered](node Node[E], v func(node Node[E]) bool) bool {
 	return true
 }
 
+// ReverseInOrder recursively traverses a binary tree "in order" from right to left, visiting the largest
+// value first.
+func ReverseInOrder[E any](node Node[E], v func(node Node[E]) bool) bool {
+	if right, ok := node.Right(); ok {
+		if !ReverseInOrder(right, v) {
+			return false
+		}
+	}
+
+	if !v(node) {
+		return false
+	}
+
+	if left, ok := node.Left(); ok {
+		if !ReverseInOrder(left, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ReversePreOrder recursively traverses a binary tree in pre order, visiting right children before left.
+func ReversePreOrder[E any](node Node[E], v func(node Node[E]) bool) bool {
+	if !v(node) {
+		return false
+	}
+
+	if right, ok := node.Right(); ok {
+		if !ReversePreOrder(right, v) {
+			return false
+		}
+	}
+
+	if left, ok := node.Left(); ok {
+		if !ReversePreOrder(left, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ReversePostOrder recursively traverses a binary tree in post order, visiting right children before left.
+func ReversePostOrder[E any](node Node[E], v func(node Node[E]) bool) bool {
+	if right, ok := node.Right(); ok {
+		if !ReversePostOrder(right, v) {
+			return false
+		}
+	}
+
+	if left, ok := node.Left(); ok {
+		if !ReversePostOrder(left, v) {
+			return false
+		}
+	}
+
+	return v(node)
+}
+
 // BreadthFirst traverses a binary tree with breadth first ordering.
-func BreadthFirst[E cmp.Ordered](node Node[E], v func(node Node[E]) bool) bool {
+func BreadthFirst[E any](node Node[E], v func(node Node[E]) bool) bool {
 	nodes := make([]Node[E], 1)
 	nodes[0] = node
 
@@ -110,10 +223,10 @@ func BreadthFirst[E cmp.Ordered](node Node[E], v func(node Node[E]) bool) bool {
 				return true
 			}
 
-			if left, ok := n.l(); ok {
+			if left, ok := n.Left(); ok {
 				children = append(children, left)
 			}
-			if right, ok := n.r(); ok {
+			if right, ok := n.Right(); ok {
 				children = append(children, right)
 			}
 		}
@@ -125,8 +238,120 @@ func BreadthFirst[E cmp.Ordered](node Node[E], v func(node Node[E]) bool) bool {
 	return true
 }
 
-// PrintTree prints a binary tree in pre-order.
-func PrintTree[E cmp.Ordered](tree Tree[E]) {
+// IterativePreOrder traverses a binary tree in pre order using an explicit stack instead of recursion,
+// so degenerate trees don't grow the call stack.
+func IterativePreOrder[E any](node Node[E], v func(node Node[E]) bool) bool {
+	stack := []Node[E]{node}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !v(n) {
+			return false
+		}
+
+		if right, ok := n.Right(); ok {
+			stack = append(stack, right)
+		}
+		if left, ok := n.Left(); ok {
+			stack = append(stack, left)
+		}
+	}
+	return true
+}
+
+// IterativeInOrder traverses a binary tree "in order" using an explicit stack instead of recursion.
+func IterativeInOrder[E any](node Node[E], v func(node Node[E]) bool) bool {
+	stack := make([]Node[E], 0)
+	n, ok := node, true
+	for ok || len(stack) > 0 {
+		for ok {
+			stack = append(stack, n)
+			n, ok = n.Left()
+		}
+
+		n = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !v(n) {
+			return false
+		}
+		n, ok = n.Right()
+	}
+	return true
+}
+
+// IterativePostOrder traverses a binary tree in post order using an explicit stack instead of recursion.
+func IterativePostOrder[E any](node Node[E], v func(node Node[E]) bool) bool {
+	stack := make([]Node[E], 0)
+	var lastVisited Node[E]
+	n, ok := node, true
+	for ok || len(stack) > 0 {
+		for ok {
+			stack = append(stack, n)
+			n, ok = n.Left()
+		}
+
+		top := stack[len(stack)-1]
+		if right, hasRight := top.Right(); hasRight && right != lastVisited {
+			n, ok = right, true
+			continue
+		}
+
+		stack = stack[:len(stack)-1]
+		if !v(top) {
+			return false
+		}
+		lastVisited = top
+	}
+	return true
+}
+
+// StacklessInOrder traverses a binary tree "in order" with O(1) extra space by following parent pointers.
+// Morris traversal reaches the same bound by temporarily threading right links, every Node already has a
+// parent link so the tree is never modified.
+func StacklessInOrder[E any](node Node[E], v func(node Node[E]) bool) bool {
+	n := leftmost(node)
+	for {
+		if !v(n) {
+			return false
+		}
+
+		if right, ok := n.Right(); ok {
+			n = leftmost(right)
+			continue
+		}
+
+		// climb until n is a left child, its parent is the next node
+		for {
+			if n == node {
+				return true
+			}
+
+			parent, _ := n.Parent()
+			if left, ok := parent.Left(); ok && left == n {
+				n = parent
+				break
+			}
+			n = parent
+		}
+	}
+}
+
+func leftmost[E any](n Node[E]) Node[E] {
+	for left, ok := n.Left(); ok; left, ok = n.Left() {
+		n = left
+	}
+	return n
+}
+
+func rightmost[E any](n Node[E]) Node[E] {
+	for right, ok := n.Right(); ok; right, ok = n.Right() {
+		n = right
+	}
+	return n
+}
+
+// PrintTree prints a binary tree in pre-order.  WriteTree draws the shape of the tree.
+func PrintTree[E any](tree Tree[E]) {
 	visitor := func(n Node[E]) bool {
 		fmt.Println(n.Value())
 		return true
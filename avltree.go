@@ -0,0 +1,326 @@
+package canopy
+
+import (
+	"cmp"
+)
+
+// AVLTree a strictly height balanced binary search tree.
+// The heights of the left and right subtrees of any node differ by at most one, which keeps lookups
+// faster than a red black tree at the cost of more rotations on Insert and Delete.  The zero value is an
+// empty tree ordered by the natural order of E, when E is a number or a string.
+type AVLTree[E any] struct {
+	avlTree[E, struct{}]
+}
+
+// avlTree is the AVL tree implementation shared by AVLTree and TreeMap.
+type avlTree[E, V any] struct {
+	root    *avlNode[E, V]
+	compare func(a, b E) int
+	size    int
+	codec   Codec[E]
+}
+
+type avlNode[E, V any] struct {
+	value   E
+	payload V
+	parent  *avlNode[E, V]
+	left    *avlNode[E, V]
+	right   *avlNode[E, V]
+	height  int
+	size    int // the number of nodes in the subtree rooted here
+}
+
+func (n *avlNode[E, V]) Value() E {
+	return n.value
+}
+
+func (n *avlNode[E, V]) Key() E {
+	return n.value
+}
+
+func (n *avlNode[E, V]) MapValue() V {
+	return n.payload
+}
+
+func (n *avlNode[E, V]) Parent() (Node[E], bool) {
+	return n.parent, n.parent != nil
+}
+
+func (n *avlNode[E, V]) Left() (Node[E], bool) {
+	return n.left, n.left != nil
+}
+
+func (n *avlNode[E, V]) Right() (Node[E], bool) {
+	return n.right, n.right != nil
+}
+
+func (n *avlNode[E, V]) IsLeaf() bool {
+	return n.left == nil && n.right == nil
+}
+
+func (n *avlNode[E, V]) count() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// nil nodes have a height of zero, leaves have a height of one.
+func avlHeight[E, V any](n *avlNode[E, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// recompute the height and subtree size of n from its children.
+func (n *avlNode[E, V]) update() {
+	n.height = 1 + max(avlHeight(n.left), avlHeight(n.right))
+	n.size = 1 + n.left.count() + n.right.count()
+}
+
+// the difference in height between the right and left subtrees.
+func (n *avlNode[E, V]) balanceFactor() int {
+	return avlHeight(n.right) - avlHeight(n.left)
+}
+
+// NewAVLTree creates a new AVL tree.
+func NewAVLTree[E cmp.Ordered]() *AVLTree[E] {
+	return NewAVLTreeFunc(cmp.Compare[E])
+}
+
+// NewAVLTreeFunc creates a new AVL tree ordered by compare, which returns a negative number when a < b,
+// a positive number when a > b and zero when a == b.
+func NewAVLTreeFunc[E any](compare func(a, b E) int) *AVLTree[E] {
+	t := new(AVLTree[E])
+	t.compare = compare
+	return t
+}
+
+// Insert Places a value into the tree, rebalancing as needed.
+// Returns true if the value was inserted, false if the value exists already.
+func (t *AVLTree[E]) Insert(value E) bool {
+	_, inserted := t.insert(value)
+	return inserted
+}
+
+// Returns the avlNode holding value, and true if the avlNode was created by this call.
+func (t *avlTree[E, V]) insert(value E) (*avlNode[E, V], bool) {
+	if resolveCompare(&t.compare) == nil {
+		panic(ErrNoComparator)
+	}
+
+	node := &avlNode[E, V]{value: value, height: 1, size: 1}
+	if t.root == nil {
+		t.root = node
+		t.size++
+		return node, true
+	}
+
+	current := t.root
+	for {
+		x := t.compare(value, current.value)
+		if x < 0 {
+			if current.left == nil {
+				current.left = node
+				node.parent = current
+				break
+			}
+			current = current.left
+		} else if x > 0 {
+			if current.right == nil {
+				current.right = node
+				node.parent = current
+				break
+			}
+			current = current.right
+		} else {
+			return current, false
+		}
+	}
+
+	t.retrace(node.parent)
+	t.size++
+	return node, true
+}
+
+// Delete Removes a value from the tree, rebalancing as needed.
+// Returns true if the value was removed.
+func (t *AVLTree[E]) Delete(value E) bool {
+	return t.remove(value) != nil
+}
+
+// Unlinks the avlNode holding value from the tree, returns the removed avlNode or nil if value wasn't found.
+func (t *avlTree[E, V]) remove(value E) *avlNode[E, V] {
+	z := avlFind(t.root, value, t.compare)
+	if z == nil {
+		return nil
+	}
+
+	// the lowest node whose height may have changed
+	var start *avlNode[E, V]
+
+	if z.left == nil {
+		start = z.parent
+		t.transplant(z, z.right)
+	} else if z.right == nil {
+		start = z.parent
+		t.transplant(z, z.left)
+	} else { // two children: replace z with its inorder successor
+		y := leftmost[E](z.right).(*avlNode[E, V])
+
+		if y.parent == z {
+			start = y
+		} else {
+			start = y.parent
+			t.transplant(y, y.right)
+			y.right = z.right
+			y.right.parent = y
+		}
+		t.transplant(z, y)
+		y.left = z.left
+		y.left.parent = y
+	}
+
+	t.retrace(start)
+
+	t.size--
+	z.parent = nil
+	z.left = nil
+	z.right = nil
+	z.height = 1
+	z.size = 1
+	return z
+}
+
+// Walk from n to the root, updating heights and rotating any node that is out of balance.
+func (t *avlTree[E, V]) retrace(n *avlNode[E, V]) {
+	for n != nil {
+		n.update()
+		n = t.rebalance(n)
+		n = n.parent
+	}
+}
+
+// Restore the AVL property at n, returns the root of the (possibly rotated) subtree.
+func (t *avlTree[E, V]) rebalance(n *avlNode[E, V]) *avlNode[E, V] {
+	bf := n.balanceFactor()
+	if bf > 1 {
+		if n.right.balanceFactor() < 0 { // right-left case
+			t.rotateRight(n.right)
+		}
+		return t.rotateLeft(n)
+	} else if bf < -1 {
+		if n.left.balanceFactor() > 0 { // left-right case
+			t.rotateLeft(n.left)
+		}
+		return t.rotateRight(n)
+	}
+	return n
+}
+
+func (t *avlTree[E, V]) rotateLeft(n *avlNode[E, V]) *avlNode[E, V] {
+	c := n.right
+	n.right = c.left
+	if n.right != nil {
+		n.right.parent = n
+	}
+	t.replaceChild(n, c)
+	c.left = n
+	n.parent = c
+
+	n.update()
+	c.update()
+	return c
+}
+
+func (t *avlTree[E, V]) rotateRight(n *avlNode[E, V]) *avlNode[E, V] {
+	c := n.left
+	n.left = c.right
+	if n.left != nil {
+		n.left.parent = n
+	}
+	t.replaceChild(n, c)
+	c.right = n
+	n.parent = c
+
+	n.update()
+	c.update()
+	return c
+}
+
+// Point the parent of n at c instead.
+func (t *avlTree[E, V]) replaceChild(n, c *avlNode[E, V]) {
+	c.parent = n.parent
+	if n.parent == nil {
+		t.root = c
+	} else if n.parent.left == n {
+		n.parent.left = c
+	} else {
+		n.parent.right = c
+	}
+}
+
+// transplant replaces the subtree rooted at u with the subtree rooted at v.
+func (t *avlTree[E, V]) transplant(u, v *avlNode[E, V]) {
+	if u.parent == nil {
+		t.root = v
+	} else if u == u.parent.left {
+		u.parent.left = v
+	} else {
+		u.parent.right = v
+	}
+
+	if v != nil {
+		v.parent = u.parent
+	}
+}
+
+func avlFind[E, V any](n *avlNode[E, V], value E, compare func(a, b E) int) *avlNode[E, V] {
+	for n != nil {
+		x := compare(value, n.value)
+		if x < 0 {
+			n = n.left
+		} else if x > 0 {
+			n = n.right
+		} else {
+			break
+		}
+	}
+	return n
+}
+
+// Find Returns true if the tree contains value.
+func (t *AVLTree[E]) Find(value E) bool {
+	return avlFind(t.root, value, t.compare) != nil
+}
+
+func (t *avlTree[E, V]) Traverse(method func(node Node[E], v func(node Node[E]) bool) bool, v func(node Node[E]) bool) {
+	if t.root != nil {
+		method(t.root, v)
+	}
+}
+
+func (t *avlTree[E, V]) rootNode() (Node[E], bool) {
+	return t.root, t.root != nil
+}
+
+func (t *avlTree[E, V]) removeValue(value E) bool {
+	return t.remove(value) != nil
+}
+
+// Len Returns the number of values in the tree.
+func (t *avlTree[E, V]) Len() int {
+	return t.size
+}
+
+// IsEmpty Returns true if the tree holds no values.
+func (t *avlTree[E, V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Clear Removes every value from the tree.
+func (t *avlTree[E, V]) Clear() {
+	t.root = nil
+	t.size = 0
+}
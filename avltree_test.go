@@ -0,0 +1,140 @@
+package canopy
+
+import (
+	"cmp"
+	"testing"
+)
+
+func TestAVL_rotations(t *testing.T) {
+	type data struct {
+		name     string
+		values   []int
+		expected []int
+	}
+
+	testData := []data{
+		{"left", []int{1, 2, 3}, []int{2, 1, 3}},
+		{"right", []int{3, 2, 1}, []int{2, 1, 3}},
+		{"left-right", []int{3, 1, 2}, []int{2, 1, 3}},
+		{"right-left", []int{1, 3, 2}, []int{2, 1, 3}},
+	}
+
+	for _, d := range testData {
+		tree := NewAVLTree[int]()
+		InsertAll(tree, d.values...)
+
+		actual := make([]int, 0, len(d.expected))
+		v := func(n Node[int]) bool {
+			actual = append(actual, n.Value())
+			return true
+		}
+		tree.Traverse(PreOrder[int], v)
+		arrayEquals(t, d.name, d.expected, actual)
+		checkAVL(t, tree)
+	}
+}
+
+func TestAVL_insertDuplicate(t *testing.T) {
+	tree := NewAVLTree[int]()
+	if !tree.Insert(42) {
+		t.Fatal("insert 42 failed")
+	}
+	if tree.Insert(42) {
+		t.Error("inserted a duplicate value")
+	}
+}
+
+func TestAVL_sortedInput(t *testing.T) {
+	tree := NewAVLTree[int]()
+	for i := 0; i < 1023; i++ {
+		tree.Insert(i)
+	}
+	checkAVL(t, tree)
+
+	if tree.root.height != 10 {
+		t.Error("expected a perfectly balanced tree of height 10, got", tree.root.height)
+	}
+
+	for i := 0; i < 1023; i++ {
+		if !tree.Find(i) {
+			t.Error("could not find", i)
+		}
+	}
+}
+
+func TestAVL_delete(t *testing.T) {
+	values := []int{
+		3, 36, 93, 61, 23, 83, 6, 25, 13, 66,
+		39, 63, 30, 20, 19, 21, 78, 72, 46, 40,
+		92, 84, 47, 24, 58, 89, 96, 26, 53, 98,
+		9, 10, 45, 11, 79, 55, 42, 90, 37, 17,
+		86, 12, 76, 28, 65, 99, 70, 44, 100,
+		29, 43, 87, 56, 51, 95, 7, 5, 50,
+	}
+	tree := NewAVLTree[int]()
+	InsertAll(tree, values...)
+	checkAVL(t, tree)
+
+	if tree.Delete(1000) {
+		t.Error("deleted a value not in the tree")
+	}
+
+	for i, v := range values {
+		if !tree.Delete(v) {
+			t.Fatal("failed to delete", v)
+		}
+		if tree.Find(v) {
+			t.Error("found", v, "after delete")
+		}
+		checkAVL(t, tree)
+
+		for _, remaining := range values[i+1:] {
+			if !tree.Find(remaining) {
+				t.Fatal("lost", remaining, "after deleting", v)
+			}
+		}
+	}
+
+	if tree.root != nil {
+		t.Error("expected an empty tree")
+	}
+}
+
+// checkAVL verifies ordering, parent pointers, heights and balance of every node in the tree.
+func checkAVL[E cmp.Ordered](t *testing.T, tree *AVLTree[E]) {
+	t.Helper()
+	if tree.root != nil && tree.root.parent != nil {
+		t.Error("root has a parent")
+	}
+
+	var check func(n *avlNode[E, struct{}]) int
+	check = func(n *avlNode[E, struct{}]) int {
+		if n == nil {
+			return 0
+		}
+
+		if n.left != nil && (n.left.parent != n || n.left.value >= n.value) {
+			t.Errorf("bad left child of %v", n.value)
+		}
+		if n.right != nil && (n.right.parent != n || n.right.value <= n.value) {
+			t.Errorf("bad right child of %v", n.value)
+		}
+
+		lh := check(n.left)
+		rh := check(n.right)
+		if lh-rh > 1 || rh-lh > 1 {
+			t.Errorf("node %v is out of balance: %d, %d", n.value, lh, rh)
+		}
+
+		if n.size != 1+n.left.count()+n.right.count() {
+			t.Errorf("node %v has the wrong subtree size %d", n.value, n.size)
+		}
+
+		h := 1 + max(lh, rh)
+		if h != n.height {
+			t.Errorf("node %v has height %d, expected %d", n.value, n.height, h)
+		}
+		return h
+	}
+	check(tree.root)
+}
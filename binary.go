@@ -0,0 +1,443 @@
+package canopy
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+)
+
+// The binary format of a tree is a header followed by the values of the tree in ascending order:
+//
+//	magic    "CNPY"
+//	version  one byte, currently 1
+//	count    the number of values, as a uvarint
+//	values   count values, each written by the element Codec
+//
+// Only the values are stored, so a tree is always read back balanced, in linear time.  WriteBinary
+// streams the values as they are visited, the encoding of the whole tree is never held in memory.
+// MarshalBinary and GobEncode use the natural encoding of numbers and strings.
+
+const binaryVersion = 1
+
+var binaryMagic = []byte("CNPY")
+
+// ErrNoCodec is returned when a tree is marshalled without a Codec and its element type isn't a number or
+// a string.
+var ErrNoCodec = errors.New("canopy: no binary codec for the element type")
+
+// CodecReader is the reader elements are decoded from.
+type CodecReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// Codec encodes the elements of a tree in the binary format.  Read must consume exactly the bytes written
+// by Append.
+type Codec[E any] interface {
+	Append(buf []byte, value E) ([]byte, error)
+	Read(r CodecReader) (E, error)
+}
+
+// VarintCodec encodes signed integers as varints.
+type VarintCodec[E ~int | ~int8 | ~int16 | ~int32 | ~int64] struct{}
+
+func (VarintCodec[E]) Append(buf []byte, value E) ([]byte, error) {
+	return binary.AppendVarint(buf, int64(value)), nil
+}
+
+func (VarintCodec[E]) Read(r CodecReader) (E, error) {
+	x, err := binary.ReadVarint(r)
+	if err != nil {
+		return 0, err
+	}
+	if int64(E(x)) != x {
+		return 0, fmt.Errorf("canopy: %d overflows %T", x, E(0))
+	}
+	return E(x), nil
+}
+
+// UvarintCodec encodes unsigned integers as uvarints.
+type UvarintCodec[E ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr] struct{}
+
+func (UvarintCodec[E]) Append(buf []byte, value E) ([]byte, error) {
+	return binary.AppendUvarint(buf, uint64(value)), nil
+}
+
+func (UvarintCodec[E]) Read(r CodecReader) (E, error) {
+	x, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, err
+	}
+	if uint64(E(x)) != x {
+		return 0, fmt.Errorf("canopy: %d overflows %T", x, E(0))
+	}
+	return E(x), nil
+}
+
+// FloatCodec encodes floating point numbers as eight little endian bytes.
+type FloatCodec[E ~float32 | ~float64] struct{}
+
+func (FloatCodec[E]) Append(buf []byte, value E) ([]byte, error) {
+	return binary.LittleEndian.AppendUint64(buf, math.Float64bits(float64(value))), nil
+}
+
+func (FloatCodec[E]) Read(r CodecReader) (E, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, err
+	}
+	return E(math.Float64frombits(binary.LittleEndian.Uint64(b[:]))), nil
+}
+
+// StringCodec encodes strings as their length, as a uvarint, followed by their bytes.
+type StringCodec[E ~string] struct{}
+
+func (StringCodec[E]) Append(buf []byte, value E) ([]byte, error) {
+	buf = binary.AppendUvarint(buf, uint64(len(value)))
+	return append(buf, value...), nil
+}
+
+func (StringCodec[E]) Read(r CodecReader) (E, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return "", err
+	}
+
+	// copy rather than allocate n bytes up front, so a corrupt length fails at the end of the input
+	var b bytes.Buffer
+	if _, err := io.CopyN(&b, r, int64(min(n, math.MaxInt64))); err != nil {
+		return "", err
+	}
+	return E(b.String()), nil
+}
+
+// naturalCodec encodes the numbers and strings of types that aren't known until run time, such as the
+// elements of a tree marshalled without a Codec.
+type naturalCodec[E any] struct{}
+
+// Returns a codec for E, or nil if E isn't a number or a string.
+func defaultCodec[E any]() Codec[E] {
+	switch reflect.TypeFor[E]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return naturalCodec[E]{}
+	}
+	return nil
+}
+
+func (c naturalCodec[E]) Append(buf []byte, value E) ([]byte, error) {
+	v := reflect.ValueOf(value)
+	switch {
+	case v.CanInt():
+		return VarintCodec[int64]{}.Append(buf, v.Int())
+	case v.CanUint():
+		return UvarintCodec[uint64]{}.Append(buf, v.Uint())
+	case v.CanFloat():
+		return FloatCodec[float64]{}.Append(buf, v.Float())
+	}
+	return StringCodec[string]{}.Append(buf, v.String())
+}
+
+func (c naturalCodec[E]) Read(r CodecReader) (E, error) {
+	var value E
+	v := reflect.ValueOf(&value).Elem()
+	switch {
+	case v.CanInt():
+		x, err := VarintCodec[int64]{}.Read(r)
+		if err != nil {
+			return value, err
+		}
+		if v.OverflowInt(x) {
+			return value, fmt.Errorf("canopy: %d overflows %T", x, value)
+		}
+		v.SetInt(x)
+	case v.CanUint():
+		x, err := UvarintCodec[uint64]{}.Read(r)
+		if err != nil {
+			return value, err
+		}
+		if v.OverflowUint(x) {
+			return value, fmt.Errorf("canopy: %d overflows %T", x, value)
+		}
+		v.SetUint(x)
+	case v.CanFloat():
+		x, err := FloatCodec[float64]{}.Read(r)
+		if err != nil {
+			return value, err
+		}
+		v.SetFloat(x)
+	default:
+		x, err := StringCodec[string]{}.Read(r)
+		if err != nil {
+			return value, err
+		}
+		v.SetString(x)
+	}
+	return value, nil
+}
+
+// Returns codec, falling back to the codec for the natural encoding of E.
+func ensureCodec[E any](codec Codec[E]) (Codec[E], error) {
+	if codec == nil {
+		codec = defaultCodec[E]()
+		if codec == nil {
+			return nil, ErrNoCodec
+		}
+	}
+	return codec, nil
+}
+
+// Writes the values of t to w in the binary format.
+func writeBinary[E any](w io.Writer, t snapshotTree[E], codec Codec[E]) error {
+	codec, err := ensureCodec(codec)
+	if err != nil {
+		return err
+	}
+
+	b := bufio.NewWriter(w)
+	b.Write(binaryMagic)
+	b.WriteByte(binaryVersion)
+
+	b.Write(binary.AppendUvarint(nil, uint64(t.Len())))
+
+	// a write error is kept by b, and returned by Flush
+	var buf []byte
+	t.Traverse(InOrder[E], func(n Node[E]) bool {
+		buf, err = codec.Append(buf[:0], n.Value())
+		b.Write(buf)
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	return b.Flush()
+}
+
+// Replaces the contents of t with values in the binary format read from r, leaving t unchanged if the
+// values can't be read or aren't in strictly ascending order.
+func readBinary[E any](r io.Reader, t snapshotTree[E], codec Codec[E]) error {
+	values, err := readValues(r, t.comparator(), codec)
+	if err != nil {
+		return err
+	}
+	t.build(values)
+	return nil
+}
+
+// Reads values in the binary format from r, and checks they are in strictly ascending order.
+func readValues[E any](r io.Reader, compare func(a, b E) int, codec Codec[E]) ([]E, error) {
+	if compare == nil {
+		return nil, ErrNoComparator
+	}
+	codec, err := ensureCodec(codec)
+	if err != nil {
+		return nil, err
+	}
+
+	cr, ok := r.(CodecReader)
+	if !ok {
+		cr = bufio.NewReader(r)
+	}
+
+	header := make([]byte, len(binaryMagic)+1)
+	if _, err := io.ReadFull(cr, header); err != nil {
+		return nil, fmt.Errorf("canopy: reading binary header: %w", err)
+	}
+	if !bytes.Equal(header[:len(binaryMagic)], binaryMagic) {
+		return nil, errors.New("canopy: not a binary tree snapshot")
+	}
+	if version := header[len(binaryMagic)]; version != binaryVersion {
+		return nil, fmt.Errorf("canopy: unsupported binary format version %d", version)
+	}
+
+	count, err := binary.ReadUvarint(cr)
+	if err != nil {
+		return nil, fmt.Errorf("canopy: reading binary header: %w", err)
+	}
+
+	// a corrupt count shouldn't allocate more than the values actually read
+	values := make([]E, 0, min(count, 1<<16))
+	for i := uint64(0); i < count; i++ {
+		value, err := codec.Read(cr)
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, fmt.Errorf("canopy: reading value %d: %w", i, err)
+		}
+		if len(values) > 0 && compare(values[len(values)-1], value) >= 0 {
+			return nil, fmt.Errorf("canopy: values are not in strictly ascending order at index %d", i)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// Returns the values of t in the binary format, encoded with codec.
+func marshalBinary[E any](t snapshotTree[E], codec Codec[E]) ([]byte, error) {
+	var b bytes.Buffer
+	err := writeBinary(&b, t, codec)
+	return b.Bytes(), err
+}
+
+// WriteBinary Writes the values of the tree to w in the binary format, encoding each value with codec, or
+// in its natural encoding if codec is nil.
+func (t *bsTree[E, V]) WriteBinary(w io.Writer, codec Codec[E]) error {
+	return writeBinary[E](w, t, codec)
+}
+
+// ReadBinary Replaces the contents of the tree with values in the binary format read from r, decoding each
+// value with codec.  Unless r is a CodecReader it may be read past the end of the tree.
+func (t *bsTree[E, V]) ReadBinary(r io.Reader, codec Codec[E]) error {
+	return readBinary[E](r, t, codec)
+}
+
+// SetCodec Sets the codec used by MarshalBinary, UnmarshalBinary, GobEncode and GobDecode, so a tree of
+// values with no natural encoding can be stored.  A nil codec restores the natural encoding.  The
+// codec isn't copied to the trees returned by Split or the set operations.
+func (t *bsTree[E, V]) SetCodec(codec Codec[E]) {
+	t.codec = codec
+}
+
+// MarshalBinary Returns the values of the tree in the binary format, encoded with the codec of the tree.
+func (t *bsTree[E, V]) MarshalBinary() ([]byte, error) {
+	return marshalBinary[E](t, t.codec)
+}
+
+// UnmarshalBinary Replaces the contents of the tree with values in the binary format.
+func (t *bsTree[E, V]) UnmarshalBinary(data []byte) error {
+	return readBinary[E](bytes.NewReader(data), t, t.codec)
+}
+
+// GobEncode Returns the values of the tree in the binary format.
+func (t *bsTree[E, V]) GobEncode() ([]byte, error) {
+	return marshalBinary[E](t, t.codec)
+}
+
+// GobDecode Replaces the contents of the tree with values in the binary format.
+func (t *bsTree[E, V]) GobDecode(data []byte) error {
+	return readBinary[E](bytes.NewReader(data), t, t.codec)
+}
+
+// WriteBinary Writes the values of the tree to w in the binary format, encoding each value with codec, or
+// in its natural encoding if codec is nil.
+func (t *splayTree[E, V]) WriteBinary(w io.Writer, codec Codec[E]) error {
+	return writeBinary[E](w, t, codec)
+}
+
+// ReadBinary Replaces the contents of the tree with values in the binary format read from r, decoding each
+// value with codec.  Unless r is a CodecReader it may be read past the end of the tree.
+func (t *splayTree[E, V]) ReadBinary(r io.Reader, codec Codec[E]) error {
+	return readBinary[E](r, t, codec)
+}
+
+// SetCodec Sets the codec used by MarshalBinary, UnmarshalBinary, GobEncode and GobDecode, so a tree of
+// values with no natural encoding can be stored.  A nil codec restores the natural encoding.  The
+// codec isn't copied to the trees returned by Split or the set operations.
+func (t *splayTree[E, V]) SetCodec(codec Codec[E]) {
+	t.codec = codec
+}
+
+// MarshalBinary Returns the values of the tree in the binary format, encoded with the codec of the tree.
+func (t *splayTree[E, V]) MarshalBinary() ([]byte, error) {
+	return marshalBinary[E](t, t.codec)
+}
+
+// UnmarshalBinary Replaces the contents of the tree with values in the binary format.
+func (t *splayTree[E, V]) UnmarshalBinary(data []byte) error {
+	return readBinary[E](bytes.NewReader(data), t, t.codec)
+}
+
+// GobEncode Returns the values of the tree in the binary format.
+func (t *splayTree[E, V]) GobEncode() ([]byte, error) {
+	return marshalBinary[E](t, t.codec)
+}
+
+// GobDecode Replaces the contents of the tree with values in the binary format.
+func (t *splayTree[E, V]) GobDecode(data []byte) error {
+	return readBinary[E](bytes.NewReader(data), t, t.codec)
+}
+
+// WriteBinary Writes the values of the tree to w in the binary format, encoding each value with codec, or
+// in its natural encoding if codec is nil.
+func (t *rbTree[E, V]) WriteBinary(w io.Writer, codec Codec[E]) error {
+	return writeBinary[E](w, t, codec)
+}
+
+// ReadBinary Replaces the contents of the tree with values in the binary format read from r, decoding each
+// value with codec.  Unless r is a CodecReader it may be read past the end of the tree.
+func (t *rbTree[E, V]) ReadBinary(r io.Reader, codec Codec[E]) error {
+	return readBinary[E](r, t, codec)
+}
+
+// SetCodec Sets the codec used by MarshalBinary, UnmarshalBinary, GobEncode and GobDecode, so a tree of
+// values with no natural encoding can be stored.  A nil codec restores the natural encoding.  The
+// codec isn't copied to the trees returned by Split or the set operations.
+func (t *rbTree[E, V]) SetCodec(codec Codec[E]) {
+	t.codec = codec
+}
+
+// MarshalBinary Returns the values of the tree in the binary format, encoded with the codec of the tree.
+func (t *rbTree[E, V]) MarshalBinary() ([]byte, error) {
+	return marshalBinary[E](t, t.codec)
+}
+
+// UnmarshalBinary Replaces the contents of the tree with values in the binary format.
+func (t *rbTree[E, V]) UnmarshalBinary(data []byte) error {
+	return readBinary[E](bytes.NewReader(data), t, t.codec)
+}
+
+// GobEncode Returns the values of the tree in the binary format.
+func (t *rbTree[E, V]) GobEncode() ([]byte, error) {
+	return marshalBinary[E](t, t.codec)
+}
+
+// GobDecode Replaces the contents of the tree with values in the binary format.
+func (t *rbTree[E, V]) GobDecode(data []byte) error {
+	return readBinary[E](bytes.NewReader(data), t, t.codec)
+}
+
+// WriteBinary Writes the values of the tree to w in the binary format, encoding each value with codec, or
+// in its natural encoding if codec is nil.
+func (t *avlTree[E, V]) WriteBinary(w io.Writer, codec Codec[E]) error {
+	return writeBinary[E](w, t, codec)
+}
+
+// ReadBinary Replaces the contents of the tree with values in the binary format read from r, decoding each
+// value with codec.  Unless r is a CodecReader it may be read past the end of the tree.
+func (t *avlTree[E, V]) ReadBinary(r io.Reader, codec Codec[E]) error {
+	return readBinary[E](r, t, codec)
+}
+
+// SetCodec Sets the codec used by MarshalBinary, UnmarshalBinary, GobEncode and GobDecode, so a tree of
+// values with no natural encoding can be stored.  A nil codec restores the natural encoding.  The
+// codec isn't copied to the trees returned by Split or the set operations.
+func (t *avlTree[E, V]) SetCodec(codec Codec[E]) {
+	t.codec = codec
+}
+
+// MarshalBinary Returns the values of the tree in the binary format, encoded with the codec of the tree.
+func (t *avlTree[E, V]) MarshalBinary() ([]byte, error) {
+	return marshalBinary[E](t, t.codec)
+}
+
+// UnmarshalBinary Replaces the contents of the tree with values in the binary format.
+func (t *avlTree[E, V]) UnmarshalBinary(data []byte) error {
+	return readBinary[E](bytes.NewReader(data), t, t.codec)
+}
+
+// GobEncode Returns the values of the tree in the binary format.
+func (t *avlTree[E, V]) GobEncode() ([]byte, error) {
+	return marshalBinary[E](t, t.codec)
+}
+
+// GobDecode Replaces the contents of the tree with values in the binary format.
+func (t *avlTree[E, V]) GobDecode(data []byte) error {
+	return readBinary[E](bytes.NewReader(data), t, t.codec)
+}
@@ -0,0 +1,236 @@
+package canopy
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// binaryTree is the part of a tree tested for the binary and gob encodings.
+type binaryTree[E any] interface {
+	validTree[E]
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+	gob.GobEncoder
+	gob.GobDecoder
+}
+
+func TestMarshalBinary(t *testing.T) {
+	expected := []byte{'C', 'N', 'P', 'Y', 1, 3, 0x03, 0x04, 0xd8, 0x04}
+	for name, tree := range treesAs[binaryTree[int]](t) {
+		InsertAll[int](tree, 300, -2, 2)
+		data, err := tree.MarshalBinary()
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		if !bytes.Equal(data, expected) {
+			t.Errorf("%s expected % x, got % x", name, expected, data)
+		}
+	}
+}
+
+func TestUnmarshalBinary(t *testing.T) {
+	values := make([]int, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		values = append(values, (i*7919)%1000-500)
+	}
+
+	for name, tree := range treesAs[binaryTree[int]](t) {
+		InsertAll[int](tree, values...)
+		data, err := tree.MarshalBinary()
+		if err != nil {
+			t.Fatal(name, err)
+		}
+
+		for other, copied := range treesAs[binaryTree[int]](t) {
+			copied.Insert(5000)
+			if err := copied.UnmarshalBinary(data); err != nil {
+				t.Fatal(name, "to", other, err)
+			}
+			if err := copied.Validate(); err != nil {
+				t.Error(name, "to", other, err)
+			}
+			if copied.Len() != 1000 || copied.Find(5000) {
+				t.Error(name, "to", other, "expected the tree to be replaced")
+			}
+		}
+	}
+}
+
+func TestGob(t *testing.T) {
+	type snapshot struct {
+		Names *RedBlackTree[string]
+		IDs   *AVLTree[uint32]
+	}
+
+	in := snapshot{NewRedBlackTree[string](), NewAVLTree[uint32]()}
+	InsertAll[string](in.Names, "carol", "alice", "bob")
+	InsertAll[uint32](in.IDs, 1, 1<<31, 20)
+
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+
+	var out snapshot
+	if err := gob.NewDecoder(&b).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	arrayEquals(t, "names", []string{"alice", "bob", "carol"}, slices.Collect(out.Names.All()))
+	arrayEquals(t, "ids", []uint32{1, 20, 1 << 31}, slices.Collect(out.IDs.All()))
+}
+
+type point struct {
+	X, Y int
+}
+
+func comparePoints(a, b point) int {
+	if a.X != b.X {
+		return a.X - b.X
+	}
+	return a.Y - b.Y
+}
+
+// pointCodec writes a point as two varints.
+type pointCodec struct{}
+
+func (pointCodec) Append(buf []byte, p point) ([]byte, error) {
+	buf = binary.AppendVarint(buf, int64(p.X))
+	return binary.AppendVarint(buf, int64(p.Y)), nil
+}
+
+func (pointCodec) Read(r CodecReader) (point, error) {
+	x, err := binary.ReadVarint(r)
+	if err != nil {
+		return point{}, err
+	}
+	y, err := binary.ReadVarint(r)
+	return point{int(x), int(y)}, err
+}
+
+func TestBinaryCodec(t *testing.T) {
+	tree := NewSplayTreeFunc(comparePoints)
+	InsertAll[point](tree, point{2, 1}, point{1, 2}, point{1, 1})
+
+	if _, err := tree.MarshalBinary(); err != ErrNoCodec {
+		t.Error("expected ErrNoCodec, got", err)
+	}
+
+	var b bytes.Buffer
+	if err := tree.WriteBinary(&b, pointCodec{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// a tree and whatever follows it in the same stream
+	b.WriteString("trailer")
+
+	copied := NewAVLTreeFunc(comparePoints)
+	if err := copied.ReadBinary(&b, pointCodec{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := slices.Collect(copied.All()); !slices.Equal(got, []point{{1, 1}, {1, 2}, {2, 1}}) {
+		t.Error("unexpected points", got)
+	}
+	if b.String() != "trailer" {
+		t.Error("expected the rest of the stream to be unread, got", b.String())
+	}
+}
+
+func TestGobCodec(t *testing.T) {
+	type snapshot struct {
+		Points *AVLTree[point]
+	}
+
+	in := snapshot{NewAVLTreeFunc(comparePoints)}
+	InsertAll[point](in.Points, point{2, 1}, point{1, 2}, point{1, 1})
+	in.Points.SetCodec(pointCodec{})
+
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+
+	// the decoding tree needs the codec too, so it has to exist before Decode
+	out := snapshot{NewAVLTreeFunc(comparePoints)}
+	out.Points.SetCodec(pointCodec{})
+	if err := gob.NewDecoder(&b).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if got := slices.Collect(out.Points.All()); !slices.Equal(got, []point{{1, 1}, {1, 2}, {2, 1}}) {
+		t.Error("unexpected points", got)
+	}
+
+	in.Points.SetCodec(nil)
+	if err := gob.NewEncoder(&b).Encode(in); err == nil {
+		t.Error("expected an error without a codec")
+	}
+}
+
+func TestBinaryCodecs(t *testing.T) {
+	floats := NewRedBlackTree[float32]()
+	InsertAll[float32](floats, 1.5, -0.25, 3)
+	var b bytes.Buffer
+	if err := floats.WriteBinary(&b, FloatCodec[float32]{}); err != nil {
+		t.Fatal(err)
+	}
+	copiedFloats := NewRedBlackTree[float32]()
+	if err := copiedFloats.ReadBinary(&b, FloatCodec[float32]{}); err != nil {
+		t.Fatal(err)
+	}
+	arrayEquals(t, "floats", []float32{-0.25, 1.5, 3}, slices.Collect(copiedFloats.All()))
+
+	type name string
+	names := NewBinarySearchTree[name]()
+	InsertAll[name](names, "b", "", "a")
+	b.Reset()
+	if err := names.WriteBinary(&b, StringCodec[name]{}); err != nil {
+		t.Fatal(err)
+	}
+	copiedNames := NewBinarySearchTree[name]()
+	if err := copiedNames.ReadBinary(&b, StringCodec[name]{}); err != nil {
+		t.Fatal(err)
+	}
+	arrayEquals(t, "names", []name{"", "a", "b"}, slices.Collect(copiedNames.All()))
+
+	// named types without a codec use their natural encoding
+	data, err := names.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var zero SplayTree[name]
+	if err := zero.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	arrayEquals(t, "zero value", []name{"", "a", "b"}, slices.Collect(zero.All()))
+}
+
+func TestUnmarshalBinaryInvalid(t *testing.T) {
+	invalid := map[string][]byte{
+		"empty":     {},
+		"magic":     []byte("JSON\x01\x00"),
+		"version":   []byte("CNPY\x02\x00"),
+		"truncated": []byte("CNPY\x01\x03\x02\x04"),
+		"order":     []byte("CNPY\x01\x02\x04\x02"),
+		"duplicate": []byte("CNPY\x01\x02\x02\x02"),
+	}
+
+	for name, data := range invalid {
+		tree := NewAVLTree[int]()
+		InsertAll[int](tree, 1, 2, 3)
+		if err := tree.UnmarshalBinary(data); err == nil {
+			t.Error(name, "expected an error")
+		}
+		arrayEquals(t, name, []int{1, 2, 3}, slices.Collect(tree.All()))
+	}
+
+	// 300 doesn't fit in an int8
+	small := NewRedBlackTree[int8]()
+	err := small.UnmarshalBinary([]byte("CNPY\x01\x01\xd8\x04"))
+	if err == nil || !strings.Contains(err.Error(), "overflows") {
+		t.Error("expected an overflow error, got", err)
+	}
+}
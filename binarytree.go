@@ -2,54 +2,112 @@ package canopy
 
 import (
 	"cmp"
+	"math/bits"
 )
 
-type bsNode[E cmp.Ordered] struct {
-	value  E
-	parent *bsNode[E]
-	left   *bsNode[E]
-	right  *bsNode[E]
+type bsNode[E, V any] struct {
+	value   E
+	payload V
+	parent  *bsNode[E, V]
+	left    *bsNode[E, V]
+	right   *bsNode[E, V]
+	size    int // the number of nodes in the subtree rooted here
 }
 
-func (n *bsNode[E]) Value() E {
+func (n *bsNode[E, V]) Value() E {
 	return n.value
 }
 
-func (n *bsNode[E]) p() (Node[E], bool) {
+func (n *bsNode[E, V]) Key() E {
+	return n.value
+}
+
+func (n *bsNode[E, V]) MapValue() V {
+	return n.payload
+}
+
+func (n *bsNode[E, V]) Parent() (Node[E], bool) {
 	return n.parent, n.parent != nil
 }
 
-func (n *bsNode[E]) l() (Node[E], bool) {
+func (n *bsNode[E, V]) Left() (Node[E], bool) {
 	return n.left, n.left != nil
 }
 
-func (n *bsNode[E]) r() (Node[E], bool) {
+func (n *bsNode[E, V]) Right() (Node[E], bool) {
 	return n.right, n.right != nil
 }
 
-// BSTree is a binary search tree.
-type BSTree[E cmp.Ordered] struct {
-	root *bsNode[E]
+func (n *bsNode[E, V]) IsLeaf() bool {
+	return n.left == nil && n.right == nil
+}
+
+func (n *bsNode[E, V]) count() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// recompute the subtree size of n from its children.
+func (n *bsNode[E, V]) update() {
+	n.size = 1 + n.left.count() + n.right.count()
+}
+
+// bsTree is the binary search tree implementation shared by BSTree and TreeMap.
+type bsTree[E, V any] struct {
+	root    *bsNode[E, V]
+	compare func(a, b E) int
+	size    int
+	codec   Codec[E]
+}
+
+// BSTree is a binary search tree.  The zero value is an empty tree ordered by the natural order of E, when
+// E is a number or a string.
+type BSTree[E any] struct {
+	bsTree[E, struct{}]
 }
 
 // NewBinarySearchTree creates a binary search tree.
 func NewBinarySearchTree[E cmp.Ordered]() *BSTree[E] {
-	return new(BSTree[E])
+	return NewBinarySearchTreeFunc(cmp.Compare[E])
+}
+
+// NewBinarySearchTreeFunc creates a binary search tree ordered by compare, which returns a negative number
+// when a < b, a positive number when a > b and zero when a == b.
+func NewBinarySearchTreeFunc[E any](compare func(a, b E) int) *BSTree[E] {
+	t := new(BSTree[E])
+	t.compare = compare
+	return t
 }
 
+// Insert Places a value into the tree.
+// Returns true if the value was inserted, false if the value exists already.
 func (t *BSTree[E]) Insert(value E) bool {
-	n := &bsNode[E]{
+	_, inserted := t.insert(value)
+	return inserted
+}
+
+// Returns the bsNode holding value, and true if the bsNode was created by this call.
+func (t *bsTree[E, V]) insert(value E) (*bsNode[E, V], bool) {
+	if resolveCompare(&t.compare) == nil {
+		panic(ErrNoComparator)
+	}
+
+	n := &bsNode[E, V]{
 		value: value,
+		size:  1,
 	}
 
 	if t.root == nil {
 		t.root = n
-		return false
+		t.size++
+		return n, true
 	}
 
 	current := t.root
 	for {
-		x := cmp.Compare(value, current.value)
+		x := t.compare(value, current.value)
 		if x < 0 {
 			if current.left == nil {
 				current.left = n
@@ -65,19 +123,129 @@ func (t *BSTree[E]) Insert(value E) bool {
 			}
 			current = current.right
 		} else {
-			return false
+			return current, false
+		}
+	}
+
+	for p := n.parent; p != nil; p = p.parent {
+		p.size++
+	}
+	t.size++
+	return n, true
+}
+
+// Balance Rebuilds the tree in place into a height balanced shape using the Day-Stout-Warren algorithm.
+// Returns the height of the tree before and after balancing.
+func (t *BSTree[E]) Balance() (before, after int) {
+	before = height(t.root)
+
+	pseudoRoot := &bsNode[E, struct{}]{right: t.root}
+	size := treeToVine(pseudoRoot)
+	leaves := size + 1 - 1<<(bits.Len(uint(size+1))-1)
+	compress(pseudoRoot, leaves)
+	size -= leaves
+	for size > 1 {
+		size /= 2
+		compress(pseudoRoot, size)
+	}
+
+	t.root = pseudoRoot.right
+	relinkParents(t.root)
+
+	after = height(t.root)
+	return before, after
+}
+
+// Flatten the tree below root into a "vine" of right children, returns the number of nodes in the vine.
+func treeToVine[E, V any](root *bsNode[E, V]) int {
+	size := 0
+	tail := root
+	rest := tail.right
+	for rest != nil {
+		if rest.left == nil {
+			tail = rest
+			rest = rest.right
+			size++
+		} else { // rotate right
+			tmp := rest.left
+			rest.left = tmp.right
+			tmp.right = rest
+			rest = tmp
+			tail.right = tmp
+		}
+	}
+	return size
+}
+
+// Perform count left rotations down the right spine starting at root.
+func compress[E, V any](root *bsNode[E, V], count int) {
+	scanner := root
+	for i := 0; i < count; i++ {
+		child := scanner.right
+		scanner.right = child.right
+		scanner = scanner.right
+		child.right = scanner.left
+		scanner.left = child
+	}
+}
+
+// Reset the parent pointers and subtree sizes of every bsNode below n.
+func relinkParents[E, V any](n *bsNode[E, V]) {
+	if n == nil {
+		return
+	}
+
+	n.parent = nil
+	visited := make([]*bsNode[E, V], 0)
+	stack := []*bsNode[E, V]{n}
+	for len(stack) > 0 {
+		n = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		visited = append(visited, n)
+		if n.left != nil {
+			n.left.parent = n
+			stack = append(stack, n.left)
 		}
+		if n.right != nil {
+			n.right.parent = n
+			stack = append(stack, n.right)
+		}
+	}
+
+	// children are visited after their parents, so walk backwards to size the children first
+	for i := len(visited) - 1; i >= 0; i-- {
+		visited[i].update()
 	}
-	return true
 }
 
-func (t *BSTree[E]) Balance() {
+// Returns the number of levels in the tree below n.  Levels are counted iteratively so degenerate trees
+// don't exhaust the stack.
+func height[E, V any](n *bsNode[E, V]) int {
+	if n == nil {
+		return 0
+	}
 
+	levels := 0
+	nodes := []*bsNode[E, V]{n}
+	for len(nodes) > 0 {
+		levels++
+		children := make([]*bsNode[E, V], 0, len(nodes)*2)
+		for _, c := range nodes {
+			if c.left != nil {
+				children = append(children, c.left)
+			}
+			if c.right != nil {
+				children = append(children, c.right)
+			}
+		}
+		nodes = children
+	}
+	return levels
 }
 
 // Find the "inorder successor starting from bsNode n.
 // The inorder successor is "the smallest key that is greater than the input bsNode
-func findInorderSuccessor[E cmp.Ordered](n *bsNode[E]) *bsNode[E] {
+func findInorderSuccessor[E, V any](n *bsNode[E, V]) *bsNode[E, V] {
 	if n.right != nil {
 		n = n.right
 		for {
@@ -100,61 +268,83 @@ func findInorderSuccessor[E cmp.Ordered](n *bsNode[E]) *bsNode[E] {
 }
 
 // A common implementation for Find and Delete.  Returns the bsNode where value is found, or nil if it is not found.
-func find[E cmp.Ordered](node *bsNode[E], value E) *bsNode[E] {
-	for node != nil && node.value != value {
-		if value < node.value {
+func find[E, V any](node *bsNode[E, V], value E, compare func(a, b E) int) *bsNode[E, V] {
+	for node != nil {
+		x := compare(value, node.value)
+		if x < 0 {
 			node = node.left
-		} else if value > node.value {
+		} else if x > 0 {
 			node = node.right
+		} else {
+			break
 		}
 	}
 	return node
 }
 
+// Delete Removes a value from the tree.
+// Returns true if the value was removed.
 func (t *BSTree[E]) Delete(value E) bool {
-	node := find(t.root, value)
+	return t.remove(value) != nil
+}
+
+// Unlinks the bsNode holding value from the tree, returns the removed bsNode or nil if value wasn't found.
+func (t *bsTree[E, V]) remove(value E) *bsNode[E, V] {
+	node := find(t.root, value, t.compare)
 	if node == nil {
-		return false
+		return nil
 	}
 
-	if node.left == nil && node.right == nil { // case 1: leaf n
-		if node.parent.value < node.value {
-			node.parent.right = nil
-		} else {
-			node.parent.left = nil
-		}
-	} else if node.left != nil && node.right != nil { // case 2:  bsNode with two children
-		// swap n with its inorder successor
-		successor := findInorderSuccessor(node)
+	// the lowest bsNode whose subtree size changed
+	start := node.parent
 
-		// swap values and unlink the successor bsNode
-		node.value = successor.value
-		if successor.value < successor.parent.value {
-			successor.parent.left = nil
-		} else {
-			successor.parent.right = nil
-		}
-		successor.parent = nil
-	} else { // case 3: bsNode one child
-		child := node.left
-		if node.left == nil {
-			child = node.right
+	if node.left == nil { // case 1: leaf, or only a right child
+		t.transplant(node, node.right)
+	} else if node.right == nil { // case 2: only a left child
+		t.transplant(node, node.left)
+	} else { // case 3:  bsNode with two children
+		// replace the bsNode with its inorder successor
+		successor := findInorderSuccessor(node)
+		start = successor
+		if successor.parent != node {
+			start = successor.parent
+			t.transplant(successor, successor.right)
+			successor.right = node.right
+			successor.right.parent = successor
 		}
+		t.transplant(node, successor)
+		successor.left = node.left
+		successor.left.parent = successor
+	}
 
-		if node == t.root {
-			t.root = child
-		} else {
-			if node.value < node.parent.value {
-				node.parent.left = child
-			} else {
-				node.parent.right = child
-			}
-		}
+	for ; start != nil; start = start.parent {
+		start.update()
+	}
+
+	t.size--
+	node.parent = nil
+	node.left = nil
+	node.right = nil
+	node.size = 1
+	return node
+}
+
+// transplant replaces the subtree rooted at u with the subtree rooted at v.
+func (t *bsTree[E, V]) transplant(u, v *bsNode[E, V]) {
+	if u.parent == nil {
+		t.root = v
+	} else if u == u.parent.left {
+		u.parent.left = v
+	} else {
+		u.parent.right = v
+	}
+
+	if v != nil {
+		v.parent = u.parent
 	}
-	return true
 }
 
-func (t *BSTree[E]) Traverse(method func(node Node[E], v func(node Node[E]) bool) bool, v func(node Node[E]) bool) {
+func (t *bsTree[E, V]) Traverse(method func(node Node[E], v func(node Node[E]) bool) bool, v func(node Node[E]) bool) {
 	if t.root != nil {
 		method(t.root, v)
 	}
@@ -162,6 +352,30 @@ func (t *BSTree[E]) Traverse(method func(node Node[E], v func(node Node[E]) bool
 
 // Find Returns true if the tree contains value.
 func (t *BSTree[E]) Find(value E) bool {
-	node := find(t.root, value)
+	node := find(t.root, value, t.compare)
 	return node != nil
 }
+
+func (t *bsTree[E, V]) rootNode() (Node[E], bool) {
+	return t.root, t.root != nil
+}
+
+func (t *bsTree[E, V]) removeValue(value E) bool {
+	return t.remove(value) != nil
+}
+
+// Len Returns the number of values in the tree.
+func (t *bsTree[E, V]) Len() int {
+	return t.size
+}
+
+// IsEmpty Returns true if the tree holds no values.
+func (t *bsTree[E, V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Clear Removes every value from the tree.
+func (t *bsTree[E, V]) Clear() {
+	t.root = nil
+	t.size = 0
+}
@@ -30,7 +30,7 @@ func TestInOrderSuccessor(t *testing.T) {
 	testData := []data{{10, 12}, {14, 20}, {8, 10}}
 
 	for _, d := range testData {
-		startNode := find(tree.root, d.val)
+		startNode := find(tree.root, d.val, tree.compare)
 
 		if startNode == nil {
 			t.Error("no start bsNode found for ", d.val)
@@ -110,3 +110,69 @@ func TestDeleteBSRoot(t *testing.T) {
 	tree.Traverse(PreOrder[int], v)
 	arrayEquals(t, "", expected, actual)
 }
+
+func TestBalanceSorted(t *testing.T) {
+	tree := NewBinarySearchTree[int]()
+	expected := make([]int, 100)
+	for i := range expected {
+		expected[i] = i
+		tree.Insert(i)
+	}
+
+	before, after := tree.Balance()
+	if before != 100 {
+		t.Error("expected height 100 before balancing, got", before)
+	}
+	if after != 7 {
+		t.Error("expected height 7 after balancing, got", after)
+	}
+
+	actual := make([]int, 0, len(expected))
+	v := func(n Node[int]) bool {
+		actual = append(actual, n.Value())
+		return true
+	}
+	tree.Traverse(InOrder[int], v)
+	arrayEquals(t, "", expected, actual)
+
+	if tree.root.parent != nil {
+		t.Error("root has a parent")
+	}
+	checkParents := func(n Node[int]) bool {
+		node := n.(*bsNode[int, struct{}])
+		if node.left != nil && node.left.parent != node || node.right != nil && node.right.parent != node {
+			t.Error("bad parent pointer below", node.value)
+		}
+		return true
+	}
+	tree.Traverse(PreOrder[int], checkParents)
+
+	for _, e := range expected {
+		if !tree.Find(e) {
+			t.Error("could not find", e, "after balancing")
+		}
+	}
+}
+
+func TestBalanceEmpty(t *testing.T) {
+	tree := NewBinarySearchTree[int]()
+	before, after := tree.Balance()
+	if before != 0 || after != 0 {
+		t.Error("expected zero height for an empty tree, got", before, after)
+	}
+}
+
+func TestDeleteBSOnlyNode(t *testing.T) {
+	tree := NewBinarySearchTree[int]()
+	tree.Insert(42)
+
+	if !tree.Delete(42) {
+		t.Fatal("delete 42 failed")
+	}
+	if tree.root != nil {
+		t.Error("expected an empty tree")
+	}
+	if tree.Delete(42) {
+		t.Error("deleted 42 from an empty tree")
+	}
+}
@@ -0,0 +1,219 @@
+package canopy
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// Build a balanced subtree of bsNodes from strictly ascending values.
+func buildBS[E, V any](sorted []E, parent *bsNode[E, V]) *bsNode[E, V] {
+	if len(sorted) == 0 {
+		return nil
+	}
+
+	mid := len(sorted) / 2
+	n := &bsNode[E, V]{value: sorted[mid], parent: parent, size: len(sorted)}
+	n.left = buildBS(sorted[:mid], n)
+	n.right = buildBS(sorted[mid+1:], n)
+	return n
+}
+
+// Build a balanced subtree of rbNodes from strictly ascending values.  Every path has the same number of
+// black nodes, the nodes on the deepest level of an incomplete tree are colored red.
+func buildRB[E, V any](sorted []E, parent *rbNode[E, V], depth, redDepth int) *rbNode[E, V] {
+	if len(sorted) == 0 {
+		return nil
+	}
+
+	mid := len(sorted) / 2
+	n := &rbNode[E, V]{value: sorted[mid], parent: parent, size: len(sorted), color: Black}
+	if depth == redDepth {
+		n.color = Red
+	}
+	n.left = buildRB(sorted[:mid], n, depth+1, redDepth)
+	n.right = buildRB(sorted[mid+1:], n, depth+1, redDepth)
+	return n
+}
+
+// Build a balanced subtree of avlNodes from strictly ascending values.
+func buildAVL[E, V any](sorted []E, parent *avlNode[E, V]) *avlNode[E, V] {
+	if len(sorted) == 0 {
+		return nil
+	}
+
+	mid := len(sorted) / 2
+	n := &avlNode[E, V]{value: sorted[mid], parent: parent}
+	n.left = buildAVL(sorted[:mid], n)
+	n.right = buildAVL(sorted[mid+1:], n)
+	n.update()
+	return n
+}
+
+// replace the contents of the tree with strictly ascending values.
+func (t *bsTree[E, V]) build(sorted []E) {
+	resolveCompare(&t.compare)
+	t.root = buildBS[E, V](sorted, nil)
+	t.size = len(sorted)
+}
+
+// replace the contents of the tree with strictly ascending values.
+func (t *splayTree[E, V]) build(sorted []E) {
+	resolveCompare(&t.compare)
+	t.root = buildBS[E, V](sorted, nil)
+	t.size = len(sorted)
+}
+
+// replace the contents of the tree with strictly ascending values.
+func (t *rbTree[E, V]) build(sorted []E) {
+	resolveCompare(&t.compare)
+	// the last level of the tree is red, unless the tree is a single node
+	redDepth := -1
+	if len(sorted) > 1 {
+		redDepth = 0
+		for n := len(sorted); n > 1; n /= 2 {
+			redDepth++
+		}
+	}
+
+	t.root = buildRB[E, V](sorted, nil, 0, redDepth)
+	t.size = len(sorted)
+}
+
+// replace the contents of the tree with strictly ascending values.
+func (t *avlTree[E, V]) build(sorted []E) {
+	resolveCompare(&t.compare)
+	t.root = buildAVL[E, V](sorted, nil)
+	t.size = len(sorted)
+}
+
+// Panics unless values are in strictly ascending order.
+func checkSorted[E any](values []E, compare func(a, b E) int) {
+	for i := 1; i < len(values); i++ {
+		if compare(values[i-1], values[i]) >= 0 {
+			panic(fmt.Sprintf("canopy: values are not in strictly ascending order at index %d", i))
+		}
+	}
+}
+
+// Returns a sorted copy of values with duplicates removed.
+func sortedUnique[E any](values []E, compare func(a, b E) int) []E {
+	sorted := slices.Clone(values)
+	slices.SortFunc(sorted, compare)
+	return slices.CompactFunc(sorted, func(a, b E) bool {
+		return compare(a, b) == 0
+	})
+}
+
+// NewBinarySearchTreeFromSorted creates a balanced binary search tree in linear time.
+// Panics if values are not in strictly ascending order.
+func NewBinarySearchTreeFromSorted[E cmp.Ordered](values []E) *BSTree[E] {
+	return NewBinarySearchTreeFromSortedFunc(values, cmp.Compare[E])
+}
+
+// NewBinarySearchTreeFromSortedFunc creates a balanced binary search tree ordered by compare in linear time.
+// Panics if values are not in strictly ascending order.
+func NewBinarySearchTreeFromSortedFunc[E any](values []E, compare func(a, b E) int) *BSTree[E] {
+	checkSorted(values, compare)
+	t := NewBinarySearchTreeFunc(compare)
+	t.build(values)
+	return t
+}
+
+// NewBinarySearchTreeFromSlice creates a balanced binary search tree from values in any order,
+// duplicate values are dropped.
+func NewBinarySearchTreeFromSlice[E cmp.Ordered](values []E) *BSTree[E] {
+	return NewBinarySearchTreeFromSliceFunc(values, cmp.Compare[E])
+}
+
+// NewBinarySearchTreeFromSliceFunc creates a balanced binary search tree ordered by compare from values
+// in any order, duplicate values are dropped.
+func NewBinarySearchTreeFromSliceFunc[E any](values []E, compare func(a, b E) int) *BSTree[E] {
+	t := NewBinarySearchTreeFunc(compare)
+	t.build(sortedUnique(values, compare))
+	return t
+}
+
+// NewSplayTreeFromSorted creates a balanced splay tree in linear time.
+// Panics if values are not in strictly ascending order.
+func NewSplayTreeFromSorted[E cmp.Ordered](values []E) *SplayTree[E] {
+	return NewSplayTreeFromSortedFunc(values, cmp.Compare[E])
+}
+
+// NewSplayTreeFromSortedFunc creates a balanced splay tree ordered by compare in linear time.
+// Panics if values are not in strictly ascending order.
+func NewSplayTreeFromSortedFunc[E any](values []E, compare func(a, b E) int) *SplayTree[E] {
+	checkSorted(values, compare)
+	t := NewSplayTreeFunc(compare)
+	t.build(values)
+	return t
+}
+
+// NewSplayTreeFromSlice creates a balanced splay tree from values in any order, duplicate values are dropped.
+func NewSplayTreeFromSlice[E cmp.Ordered](values []E) *SplayTree[E] {
+	return NewSplayTreeFromSliceFunc(values, cmp.Compare[E])
+}
+
+// NewSplayTreeFromSliceFunc creates a balanced splay tree ordered by compare from values in any order,
+// duplicate values are dropped.
+func NewSplayTreeFromSliceFunc[E any](values []E, compare func(a, b E) int) *SplayTree[E] {
+	t := NewSplayTreeFunc(compare)
+	t.build(sortedUnique(values, compare))
+	return t
+}
+
+// NewRedBlackTreeFromSorted creates a red black tree in linear time.
+// Panics if values are not in strictly ascending order.
+func NewRedBlackTreeFromSorted[E cmp.Ordered](values []E) *RedBlackTree[E] {
+	return NewRedBlackTreeFromSortedFunc(values, cmp.Compare[E])
+}
+
+// NewRedBlackTreeFromSortedFunc creates a red black tree ordered by compare in linear time.
+// Panics if values are not in strictly ascending order.
+func NewRedBlackTreeFromSortedFunc[E any](values []E, compare func(a, b E) int) *RedBlackTree[E] {
+	checkSorted(values, compare)
+	t := NewRedBlackTreeFunc(compare)
+	t.build(values)
+	return t
+}
+
+// NewRedBlackTreeFromSlice creates a red black tree from values in any order, duplicate values are dropped.
+func NewRedBlackTreeFromSlice[E cmp.Ordered](values []E) *RedBlackTree[E] {
+	return NewRedBlackTreeFromSliceFunc(values, cmp.Compare[E])
+}
+
+// NewRedBlackTreeFromSliceFunc creates a red black tree ordered by compare from values in any order,
+// duplicate values are dropped.
+func NewRedBlackTreeFromSliceFunc[E any](values []E, compare func(a, b E) int) *RedBlackTree[E] {
+	t := NewRedBlackTreeFunc(compare)
+	t.build(sortedUnique(values, compare))
+	return t
+}
+
+// NewAVLTreeFromSorted creates an AVL tree in linear time.
+// Panics if values are not in strictly ascending order.
+func NewAVLTreeFromSorted[E cmp.Ordered](values []E) *AVLTree[E] {
+	return NewAVLTreeFromSortedFunc(values, cmp.Compare[E])
+}
+
+// NewAVLTreeFromSortedFunc creates an AVL tree ordered by compare in linear time.
+// Panics if values are not in strictly ascending order.
+func NewAVLTreeFromSortedFunc[E any](values []E, compare func(a, b E) int) *AVLTree[E] {
+	checkSorted(values, compare)
+	t := NewAVLTreeFunc(compare)
+	t.build(values)
+	return t
+}
+
+// NewAVLTreeFromSlice creates an AVL tree from values in any order, duplicate values are dropped.
+func NewAVLTreeFromSlice[E cmp.Ordered](values []E) *AVLTree[E] {
+	return NewAVLTreeFromSliceFunc(values, cmp.Compare[E])
+}
+
+// NewAVLTreeFromSliceFunc creates an AVL tree ordered by compare from values in any order,
+// duplicate values are dropped.
+func NewAVLTreeFromSliceFunc[E any](values []E, compare func(a, b E) int) *AVLTree[E] {
+	t := NewAVLTreeFunc(compare)
+	t.build(sortedUnique(values, compare))
+	return t
+}
@@ -0,0 +1,72 @@
+package canopy
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFromSorted(t *testing.T) {
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = i * 3
+	}
+
+	trees := map[string]Tree[int]{
+		"bst":      NewBinarySearchTreeFromSorted(values),
+		"splay":    NewSplayTreeFromSorted(values),
+		"redblack": NewRedBlackTreeFromSorted(values),
+		"avl":      NewAVLTreeFromSorted(values),
+	}
+
+	for name, tree := range trees {
+		if tree.Len() != len(values) {
+			t.Error(name, "expected", len(values), "values, got", tree.Len())
+		}
+
+		actual := make([]int, 0, len(values))
+		tree.Traverse(InOrder[int], func(n Node[int]) bool {
+			actual = append(actual, n.Value())
+			return true
+		})
+		arrayEquals(t, name, values, actual)
+
+		for _, v := range values {
+			if !tree.Find(v) || tree.Find(v+1) {
+				t.Fatal(name, "search failed for", v)
+			}
+		}
+	}
+
+	bst := NewBinarySearchTreeFromSorted(values)
+	if before, _ := bst.Balance(); before != 10 {
+		t.Error("expected a tree of height 10, got", before)
+	}
+
+	checkRedBlack(t, NewRedBlackTreeFromSorted(values))
+	checkAVL(t, NewAVLTreeFromSorted(values))
+}
+
+func TestFromSortedPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for unsorted values")
+		}
+	}()
+	NewRedBlackTreeFromSorted([]int{1, 3, 3, 4})
+}
+
+func TestFromSlice(t *testing.T) {
+	values := []int{5, 3, 9, 3, 1, 9, 7}
+	expected := []int{1, 3, 5, 7, 9}
+
+	arrayEquals(t, "bst", expected, slices.Collect(NewBinarySearchTreeFromSlice(values).All()))
+	arrayEquals(t, "splay", expected, slices.Collect(NewSplayTreeFromSlice(values).All()))
+	arrayEquals(t, "avl", expected, slices.Collect(NewAVLTreeFromSlice(values).All()))
+
+	tree := NewRedBlackTreeFromSlice(values)
+	arrayEquals(t, "redblack", expected, slices.Collect(tree.All()))
+	checkRedBlack(t, tree)
+
+	// the input is left alone
+	arrayEquals(t, "input", []int{5, 3, 9, 3, 1, 9, 7}, values)
+}
@@ -7,6 +7,30 @@ import (
 	"testing"
 )
 
+// allTrees returns an empty tree of every type, keyed by the name used in test failures.
+func allTrees() map[string]Tree[int] {
+	return map[string]Tree[int]{
+		"bst":      NewBinarySearchTree[int](),
+		"splay":    NewSplayTree[int](),
+		"redblack": NewRedBlackTree[int](),
+		"avl":      NewAVLTree[int](),
+	}
+}
+
+// treesAs returns allTrees as T, the interface a test needs, failing the test if a tree doesn't implement it.
+func treesAs[T any](t testing.TB) map[string]T {
+	t.Helper()
+	trees := make(map[string]T)
+	for name, tree := range allTrees() {
+		as, ok := tree.(T)
+		if !ok {
+			t.Fatalf("%s tree does not implement %s", name, reflect.TypeFor[T]())
+		}
+		trees[name] = as
+	}
+	return trees
+}
+
 func TestTraversals(t *testing.T) {
 	type traverseFunc func(node Node[int], v func(node Node[int]) bool) bool
 	funcs := []traverseFunc{PreOrder[int], PostOrder[int], InOrder[int], BreadthFirst[int]}
@@ -34,3 +58,101 @@ func TestTraversals(t *testing.T) {
 		actual = actual[:0]
 	}
 }
+
+func TestIterativeTraversals(t *testing.T) {
+	type traverseFunc func(node Node[int], v func(node Node[int]) bool) bool
+	funcs := []traverseFunc{IterativePreOrder[int], IterativePostOrder[int], IterativeInOrder[int], StacklessInOrder[int]}
+	recursive := []traverseFunc{PreOrder[int], PostOrder[int], InOrder[int], InOrder[int]}
+
+	tree := NewBinarySearchTree[int]()
+	InsertAll(tree, 50, 20, 80, 10, 30, 70, 90, 5, 15, 25, 35, 60, 75, 85, 95, 33, 37, 1)
+
+	for i, f := range funcs {
+		errorPrefix := fmt.Sprintf("%s:", runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name())
+		expected := make([]int, 0)
+		tree.Traverse(recursive[i], func(n Node[int]) bool {
+			expected = append(expected, n.Value())
+			return true
+		})
+
+		actual := make([]int, 0)
+		tree.Traverse(f, func(n Node[int]) bool {
+			actual = append(actual, n.Value())
+			return true
+		})
+		arrayEquals(t, errorPrefix, expected, actual)
+
+		// stop early
+		actual = actual[:0]
+		tree.Traverse(f, func(n Node[int]) bool {
+			actual = append(actual, n.Value())
+			return len(actual) < 5
+		})
+		arrayEquals(t, errorPrefix, expected[:5], actual)
+	}
+}
+
+func TestStacklessInOrderSubtree(t *testing.T) {
+	tree := NewBinarySearchTree[int]()
+	InsertAll(tree, 50, 20, 80, 10, 30, 70, 90)
+
+	actual := make([]int, 0)
+	StacklessInOrder[int](tree.root.left, func(n Node[int]) bool {
+		actual = append(actual, n.Value())
+		return true
+	})
+	arrayEquals(t, "", []int{10, 20, 30}, actual)
+}
+
+func TestIterativeDegenerate(t *testing.T) {
+	// a chain of right children, as a binary search tree built from sorted input would be
+	const size = 500000
+	tree := NewBinarySearchTree[int]()
+	tree.root = &bsNode[int, struct{}]{value: 0, size: size}
+	n := tree.root
+	for i := 1; i < size; i++ {
+		n.right = &bsNode[int, struct{}]{value: i, parent: n, size: size - i}
+		n = n.right
+	}
+
+	funcs := []func(node Node[int], v func(node Node[int]) bool) bool{
+		IterativePreOrder[int], IterativePostOrder[int], IterativeInOrder[int], StacklessInOrder[int],
+	}
+	for _, f := range funcs {
+		count := 0
+		tree.Traverse(f, func(n Node[int]) bool {
+			count++
+			return true
+		})
+		if count != size {
+			t.Error("expected", size, "nodes, got", count)
+		}
+	}
+}
+
+func TestReverseTraversals(t *testing.T) {
+	type traverseFunc func(node Node[int], v func(node Node[int]) bool) bool
+	funcs := []traverseFunc{ReversePreOrder[int], ReversePostOrder[int], ReverseInOrder[int]}
+	data := []int{100, 20, 200, 10, 30, 150, 300}
+	expected := [][]int{
+		{100, 200, 300, 150, 20, 30, 10},
+		{300, 150, 200, 30, 10, 20, 100},
+		{300, 200, 150, 100, 30, 20, 10},
+	}
+
+	tree := NewBinarySearchTree[int]()
+	InsertAll(tree, data...)
+
+	actual := make([]int, 0, len(data))
+	visitor := func(n Node[int]) bool {
+		actual = append(actual, n.Value())
+		return true
+	}
+
+	for i, e := range expected {
+		tree.Traverse(funcs[i], visitor)
+		errorPrefix := fmt.Sprintf("%s:", runtime.FuncForPC(reflect.ValueOf(funcs[i]).Pointer()).Name())
+		arrayEquals(t, errorPrefix, e, actual)
+		actual = actual[:0]
+	}
+}
@@ -0,0 +1,97 @@
+package canopy
+
+import (
+	"cmp"
+	"errors"
+	"reflect"
+)
+
+// ErrNoComparator is returned when an operation needs to compare values in a tree that has no comparator,
+// such as a tree from outside this package or a zero value tree of a type with no natural order.
+var ErrNoComparator = errors.New("canopy: tree has no comparator")
+
+// Returns a comparison function for the natural order of E, or nil if E isn't a number or a string.  This
+// orders the zero value of a tree, which has no comparison function of its own.
+func naturalCompare[E any]() func(a, b E) int {
+	// the common types avoid reflection
+	var compare any
+	switch any(*new(E)).(type) {
+	case int:
+		compare = cmp.Compare[int]
+	case int64:
+		compare = cmp.Compare[int64]
+	case int32:
+		compare = cmp.Compare[int32]
+	case uint:
+		compare = cmp.Compare[uint]
+	case uint64:
+		compare = cmp.Compare[uint64]
+	case uint32:
+		compare = cmp.Compare[uint32]
+	case float64:
+		compare = cmp.Compare[float64]
+	case string:
+		compare = cmp.Compare[string]
+	}
+	if compare != nil {
+		return compare.(func(a, b E) int)
+	}
+
+	switch reflect.TypeFor[E]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b E) int {
+			return cmp.Compare(reflect.ValueOf(a).Int(), reflect.ValueOf(b).Int())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b E) int {
+			return cmp.Compare(reflect.ValueOf(a).Uint(), reflect.ValueOf(b).Uint())
+		}
+	case reflect.Float32, reflect.Float64:
+		return func(a, b E) int {
+			return cmp.Compare(reflect.ValueOf(a).Float(), reflect.ValueOf(b).Float())
+		}
+	case reflect.String:
+		return func(a, b E) int {
+			return cmp.Compare(reflect.ValueOf(a).String(), reflect.ValueOf(b).String())
+		}
+	}
+	return nil
+}
+
+// Sets *compare to the natural order of E if the tree doesn't have a comparison function yet.  Called when a
+// tree takes its first values, so the read paths never have to write to the tree.
+func resolveCompare[E any](compare *func(a, b E) int) func(a, b E) int {
+	if *compare == nil {
+		*compare = naturalCompare[E]()
+	}
+	return *compare
+}
+
+// Returns the comparison function of the tree, a zero value tree falls back to the natural order of E.
+func (t *bsTree[E, V]) comparator() func(a, b E) int {
+	if t.compare != nil {
+		return t.compare
+	}
+	return naturalCompare[E]()
+}
+
+func (t *splayTree[E, V]) comparator() func(a, b E) int {
+	if t.compare != nil {
+		return t.compare
+	}
+	return naturalCompare[E]()
+}
+
+func (t *rbTree[E, V]) comparator() func(a, b E) int {
+	if t.compare != nil {
+		return t.compare
+	}
+	return naturalCompare[E]()
+}
+
+func (t *avlTree[E, V]) comparator() func(a, b E) int {
+	if t.compare != nil {
+		return t.compare
+	}
+	return naturalCompare[E]()
+}
@@ -0,0 +1,137 @@
+package canopy
+
+import (
+	"iter"
+	"sync"
+)
+
+// ConcurrentTree makes any Tree safe for use by multiple goroutines.  Operations which only read the tree
+// share a read lock, operations which modify it take the write lock.
+//
+// Whether an operation only reads depends on the tree:
+//
+//   - BSTree, RedBlackTree and AVLTree: Find, Traverse, Len, IsEmpty and every query, such as Min, Floor,
+//     Range, Select and the iterators, only read the tree.
+//   - SplayTree: Find, Min, Max, Floor, Ceiling, Lower, Higher, Select and Rank splay the tree and so
+//     modify it.  Traverse, Len, IsEmpty, Range and the iterators only read the tree.
+//
+// A ConcurrentTree wrapping a SplayTree takes the write lock for Find and View, so concurrent lookups are
+// serialized.  Use NewConcurrentTreeExclusive for a Tree from another package which modifies itself on
+// reads.
+//
+// Nodes passed to a Traverse visitor must not be kept once Traverse returns, and the visitor, the body of
+// a loop over an iterator and the function passed to View or Update must not call other methods of the
+// ConcurrentTree, which would deadlock.
+type ConcurrentTree[E any] struct {
+	mu        sync.RWMutex
+	tree      Tree[E]
+	exclusive bool // lookups modify the tree
+}
+
+// NewConcurrentTree wraps tree, which must not be used directly once it is wrapped.
+func NewConcurrentTree[E any](tree Tree[E]) *ConcurrentTree[E] {
+	_, splays := tree.(*SplayTree[E])
+	return &ConcurrentTree[E]{tree: tree, exclusive: splays}
+}
+
+// NewConcurrentTreeExclusive wraps tree, taking the write lock for Find and View as well as for the
+// operations which modify the tree.  tree must not be used directly once it is wrapped.
+func NewConcurrentTreeExclusive[E any](tree Tree[E]) *ConcurrentTree[E] {
+	return &ConcurrentTree[E]{tree: tree, exclusive: true}
+}
+
+// lock the tree for a lookup, which only needs the read lock when lookups don't modify the tree.
+func (c *ConcurrentTree[E]) lookupLock() func() {
+	if c.exclusive {
+		c.mu.Lock()
+		return c.mu.Unlock
+	}
+	c.mu.RLock()
+	return c.mu.RUnlock
+}
+
+// Insert Places a value into the tree.
+// Returns true if the value was inserted, false if the value exists already.
+func (c *ConcurrentTree[E]) Insert(value E) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tree.Insert(value)
+}
+
+// Delete Removes a value from the tree.
+// Returns true if the value was removed.
+func (c *ConcurrentTree[E]) Delete(value E) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tree.Delete(value)
+}
+
+// Find Returns true if the tree contains value.
+func (c *ConcurrentTree[E]) Find(value E) bool {
+	defer c.lookupLock()()
+	return c.tree.Find(value)
+}
+
+// Traverse Visits the nodes of the tree while holding the read lock.
+func (c *ConcurrentTree[E]) Traverse(method func(node Node[E], v func(node Node[E]) bool) bool, visitor func(node Node[E]) bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.tree.Traverse(method, visitor)
+}
+
+// Len Returns the number of values in the tree.
+func (c *ConcurrentTree[E]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tree.Len()
+}
+
+// IsEmpty Returns true if the tree holds no values.
+func (c *ConcurrentTree[E]) IsEmpty() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tree.IsEmpty()
+}
+
+// Clear Removes every value from the tree.
+func (c *ConcurrentTree[E]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tree.Clear()
+}
+
+// View Calls f with the wrapped tree while holding a lock for lookups, so f can run several queries
+// against one version of the tree.  f must not modify the tree.
+func (c *ConcurrentTree[E]) View(f func(tree Tree[E])) {
+	defer c.lookupLock()()
+	f(c.tree)
+}
+
+// Update Calls f with the wrapped tree while holding the write lock, so f can make several changes
+// atomically.
+func (c *ConcurrentTree[E]) Update(f func(tree Tree[E])) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f(c.tree)
+}
+
+// Returns an iterator which holds the read lock from the first value until the loop ends.
+func (c *ConcurrentTree[E]) locked(method func(node Node[E], v func(node Node[E]) bool) bool) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		traversalSeq[E](c.tree, method)(yield)
+	}
+}
+
+// All Returns an iterator over the values of the tree in ascending order.  The read lock is held until
+// the loop ends, so writers wait for the loop.
+func (c *ConcurrentTree[E]) All() iter.Seq[E] {
+	return c.locked(InOrder[E])
+}
+
+// Backward Returns an iterator over the values of the tree in descending order.  The read lock is held
+// until the loop ends, so writers wait for the loop.
+func (c *ConcurrentTree[E]) Backward() iter.Seq[E] {
+	return c.locked(ReverseInOrder[E])
+}
@@ -0,0 +1,129 @@
+package canopy
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentTree(t *testing.T) {
+	const writers, readers, values = 4, 4, 500
+
+	for name, wrapped := range allTrees() {
+		tree := NewConcurrentTree(wrapped)
+		var wg sync.WaitGroup
+		for w := 0; w < writers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := w; i < values; i += writers {
+					tree.Insert(i)
+				}
+			}()
+		}
+		for r := 0; r < readers; r++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < values; i++ {
+					tree.Find(i)
+					tree.Len()
+					for range tree.All() {
+						break
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		if tree.Len() != values {
+			t.Error(name, "expected", values, "values, got", tree.Len())
+		}
+		tree.View(func(tree Tree[int]) {
+			if err := tree.(validTree[int]).Validate(); err != nil {
+				t.Error(name, err)
+			}
+		})
+
+		// delete the odd values while reading
+		for w := 0; w < writers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 1 + 2*w; i < values; i += 2 * writers {
+					tree.Delete(i)
+				}
+			}()
+		}
+		for r := 0; r < readers; r++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < values; i += 2 {
+					if !tree.Find(i) {
+						t.Error(name, "lost", i)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		if tree.Len() != values/2 {
+			t.Error(name, "expected", values/2, "values, got", tree.Len())
+		}
+	}
+}
+
+func TestConcurrentTreeIterators(t *testing.T) {
+	tree := NewConcurrentTree[int](NewRedBlackTree[int]())
+	InsertAll[int](tree, 3, 1, 2)
+
+	arrayEquals(t, "all", []int{1, 2, 3}, slices.Collect(tree.All()))
+	arrayEquals(t, "backward", []int{3, 2, 1}, slices.Collect(tree.Backward()))
+
+	// the lock is released when a loop breaks early
+	for range tree.All() {
+		break
+	}
+	tree.Clear()
+	if !tree.IsEmpty() {
+		t.Error("expected an empty tree")
+	}
+}
+
+func TestConcurrentTreeUpdate(t *testing.T) {
+	tree := NewConcurrentTree[int](NewAVLTree[int]())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// move the largest value up by one, which is only safe under one lock
+			tree.Update(func(tree Tree[int]) {
+				avl := tree.(*AVLTree[int])
+				max, _ := avl.Max()
+				avl.Insert(max + 1)
+			})
+		}()
+	}
+	wg.Wait()
+
+	tree.View(func(tree Tree[int]) {
+		if max, _ := tree.(*AVLTree[int]).Max(); max != 100 || tree.Len() != 100 {
+			t.Error("expected values up to 100, got", max, tree.Len())
+		}
+	})
+}
+
+func TestConcurrentTreeExclusive(t *testing.T) {
+	if !NewConcurrentTree[int](NewSplayTree[int]()).exclusive {
+		t.Error("expected a splay tree to take the write lock for lookups")
+	}
+	if NewConcurrentTree[int](NewRedBlackTree[int]()).exclusive {
+		t.Error("expected a red black tree to share the read lock for lookups")
+	}
+	if !NewConcurrentTreeExclusive[int](NewBinarySearchTree[int]()).exclusive {
+		t.Error("expected an exclusive tree to take the write lock for lookups")
+	}
+}
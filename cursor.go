@@ -0,0 +1,158 @@
+package canopy
+
+// Cursor walks the values of a tree in either direction.  A cursor stays valid while the tree is modified,
+// as long as the current value isn't removed by anything other than DeleteCurrent.
+// Moving a cursor never splays a SplayTree.
+type Cursor[E any] struct {
+	tree searchTree[E]
+	node Node[E]
+}
+
+func newCursor[E any](tree searchTree[E]) *Cursor[E] {
+	return &Cursor[E]{tree: tree}
+}
+
+// Valid Returns true if the cursor is positioned at a value.
+func (c *Cursor[E]) Valid() bool {
+	return c.node != nil
+}
+
+// Value Returns the value at the cursor, or the zero value if the cursor isn't valid.
+func (c *Cursor[E]) Value() E {
+	value, _ := nodeValue(c.node)
+	return value
+}
+
+// First Moves the cursor to the smallest value, returns false if the tree is empty.
+func (c *Cursor[E]) First() bool {
+	c.node = nil
+	if root, ok := c.tree.rootNode(); ok {
+		c.node = leftmost(root)
+	}
+	return c.Valid()
+}
+
+// Last Moves the cursor to the largest value, returns false if the tree is empty.
+func (c *Cursor[E]) Last() bool {
+	c.node = nil
+	if root, ok := c.tree.rootNode(); ok {
+		c.node = rightmost(root)
+	}
+	return c.Valid()
+}
+
+// Seek Moves the cursor to value, returns false and invalidates the cursor if value isn't in the tree.
+func (c *Cursor[E]) Seek(value E) bool {
+	c.seek(value, true)
+	if c.node != nil && c.tree.comparator()(c.node.Value(), value) != 0 {
+		c.node = nil
+	}
+	return c.Valid()
+}
+
+// SeekFloor Moves the cursor to the greatest value less than or equal to value, returns false and
+// invalidates the cursor if there isn't one.
+func (c *Cursor[E]) SeekFloor(value E) bool {
+	c.seek(value, true)
+	return c.Valid()
+}
+
+// SeekCeiling Moves the cursor to the smallest value greater than or equal to value, returns false and
+// invalidates the cursor if there isn't one.
+func (c *Cursor[E]) SeekCeiling(value E) bool {
+	c.seek(value, false)
+	return c.Valid()
+}
+
+func (c *Cursor[E]) seek(value E, below bool) {
+	c.node = nil
+	if root, ok := c.tree.rootNode(); ok {
+		c.node, _ = nearest(root, value, c.tree.comparator(), below, true)
+	}
+}
+
+// Next Moves the cursor to the next larger value, returns false and invalidates the cursor at the end.
+func (c *Cursor[E]) Next() bool {
+	if c.node != nil {
+		c.node = successor(c.node)
+	}
+	return c.Valid()
+}
+
+// Prev Moves the cursor to the next smaller value, returns false and invalidates the cursor at the start.
+func (c *Cursor[E]) Prev() bool {
+	if c.node != nil {
+		c.node = predecessor(c.node)
+	}
+	return c.Valid()
+}
+
+// DeleteCurrent Removes the value at the cursor from the tree and moves the cursor to the next larger value.
+// Returns false if the cursor wasn't valid.
+func (c *Cursor[E]) DeleteCurrent() bool {
+	if c.node == nil {
+		return false
+	}
+
+	// nodes are relinked rather than copied on delete, so the successor is still in the tree afterwards
+	next := successor(c.node)
+	c.tree.removeValue(c.node.Value())
+	c.node = next
+	return true
+}
+
+// Returns the node with the next larger value, or nil.
+func successor[E any](n Node[E]) Node[E] {
+	if right, ok := n.Right(); ok {
+		return leftmost(right)
+	}
+
+	for {
+		parent, ok := n.Parent()
+		if !ok {
+			return nil
+		}
+		if left, ok := parent.Left(); ok && left == n {
+			return parent
+		}
+		n = parent
+	}
+}
+
+// Returns the node with the next smaller value, or nil.
+func predecessor[E any](n Node[E]) Node[E] {
+	if left, ok := n.Left(); ok {
+		return rightmost(left)
+	}
+
+	for {
+		parent, ok := n.Parent()
+		if !ok {
+			return nil
+		}
+		if right, ok := parent.Right(); ok && right == n {
+			return parent
+		}
+		n = parent
+	}
+}
+
+// Cursor Returns a cursor over the tree, which isn't positioned until First, Last or a Seek is called.
+func (t *bsTree[E, V]) Cursor() *Cursor[E] {
+	return newCursor[E](t)
+}
+
+// Cursor Returns a cursor over the tree, which isn't positioned until First, Last or a Seek is called.
+func (t *splayTree[E, V]) Cursor() *Cursor[E] {
+	return newCursor[E](t)
+}
+
+// Cursor Returns a cursor over the tree, which isn't positioned until First, Last or a Seek is called.
+func (t *rbTree[E, V]) Cursor() *Cursor[E] {
+	return newCursor[E](t)
+}
+
+// Cursor Returns a cursor over the tree, which isn't positioned until First, Last or a Seek is called.
+func (t *avlTree[E, V]) Cursor() *Cursor[E] {
+	return newCursor[E](t)
+}
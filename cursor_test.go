@@ -0,0 +1,113 @@
+package canopy
+
+import (
+	"slices"
+	"testing"
+)
+
+// cursorOwner is the part of a tree tested for cursors.
+type cursorOwner[E any] interface {
+	Tree[E]
+	Cursor() *Cursor[E]
+}
+
+func TestCursorWalk(t *testing.T) {
+	for name, tree := range treesAs[cursorOwner[int]](t) {
+		InsertAll[int](tree, 50, 20, 80, 10, 30, 70, 90, 25)
+
+		c := tree.Cursor()
+		if c.Valid() {
+			t.Error(name, "new cursor is valid")
+		}
+
+		forward := make([]int, 0)
+		for ok := c.First(); ok; ok = c.Next() {
+			forward = append(forward, c.Value())
+		}
+		arrayEquals(t, name+" forward", []int{10, 20, 25, 30, 50, 70, 80, 90}, forward)
+
+		backward := make([]int, 0)
+		for ok := c.Last(); ok; ok = c.Prev() {
+			backward = append(backward, c.Value())
+		}
+		arrayEquals(t, name+" backward", []int{90, 80, 70, 50, 30, 25, 20, 10}, backward)
+
+		if c.Next() || c.Prev() {
+			t.Error(name, "moved an invalid cursor")
+		}
+	}
+}
+
+func TestCursorSeek(t *testing.T) {
+	for name, tree := range treesAs[cursorOwner[int]](t) {
+		InsertAll[int](tree, 50, 20, 80, 10, 30, 70, 90)
+		c := tree.Cursor()
+
+		if !c.Seek(30) || c.Value() != 30 {
+			t.Error(name, "Seek 30 failed")
+		}
+		if !c.Next() || c.Value() != 50 {
+			t.Error(name, "expected 50 after 30, got", c.Value())
+		}
+		if c.Seek(35) {
+			t.Error(name, "Seek found a missing value")
+		}
+
+		if !c.SeekFloor(35) || c.Value() != 30 {
+			t.Error(name, "expected floor 30, got", c.Value())
+		}
+		if !c.SeekCeiling(35) || c.Value() != 50 {
+			t.Error(name, "expected ceiling 50, got", c.Value())
+		}
+		if c.SeekFloor(5) || c.SeekCeiling(95) {
+			t.Error(name, "seek past the ends of the tree succeeded")
+		}
+	}
+}
+
+func TestCursorDeleteCurrent(t *testing.T) {
+	for name, tree := range treesAs[cursorOwner[int]](t) {
+		InsertAll[int](tree, 50, 20, 80, 10, 30, 70, 90, 25, 75, 95)
+
+		// delete every other value while walking forward
+		c := tree.Cursor()
+		keep := true
+		for ok := c.First(); ok; {
+			if keep {
+				ok = c.Next()
+			} else {
+				c.DeleteCurrent()
+				ok = c.Valid()
+			}
+			keep = !keep
+		}
+
+		actual := make([]int, 0)
+		tree.Traverse(InOrder[int], func(n Node[int]) bool {
+			actual = append(actual, n.Value())
+			return true
+		})
+		arrayEquals(t, name, []int{10, 25, 50, 75, 90}, actual)
+		if tree.Len() != 5 {
+			t.Error(name, "expected 5 values, got", tree.Len())
+		}
+
+		if c.DeleteCurrent() {
+			t.Error(name, "deleted with an invalid cursor")
+		}
+	}
+}
+
+func TestCursorDeleteAll(t *testing.T) {
+	tree := NewRedBlackTreeFromSorted([]int{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	c := tree.Cursor()
+	c.First()
+	for c.Valid() {
+		c.DeleteCurrent()
+		checkRedBlack(t, tree)
+	}
+
+	if !tree.IsEmpty() {
+		t.Error("expected an empty tree, got", slices.Collect(tree.All()))
+	}
+}
@@ -0,0 +1,186 @@
+package canopy
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DOTOption configures WriteDOT.
+type DOTOption[E any] func(c *dotConfig[E])
+
+type dotConfig[E any] struct {
+	name      string
+	root      bool
+	path      []E
+	highlight []func(node Node[E]) bool
+}
+
+// DOTName Sets the name of the digraph, the default is "canopy".
+func DOTName[E any](name string) DOTOption[E] {
+	return func(c *dotConfig[E]) {
+		c.name = name
+	}
+}
+
+// HighlightRoot Highlights the root node, which in a SplayTree is the most recently accessed value.
+func HighlightRoot[E any]() DOTOption[E] {
+	return func(c *dotConfig[E]) {
+		c.root = true
+	}
+}
+
+// HighlightPath Highlights every node visited while searching the tree for value.
+func HighlightPath[E any](value E) DOTOption[E] {
+	return func(c *dotConfig[E]) {
+		c.path = append(c.path, value)
+	}
+}
+
+// HighlightFunc Highlights every node for which highlight returns true.
+func HighlightFunc[E any](highlight func(node Node[E]) bool) DOTOption[E] {
+	return func(c *dotConfig[E]) {
+		c.highlight = append(c.highlight, highlight)
+	}
+}
+
+// Returns the root node of tree, or false if the tree is empty.
+func rootOf[E any](tree Tree[E]) (Node[E], bool) {
+	var root Node[E]
+	tree.Traverse(func(node Node[E], v func(node Node[E]) bool) bool {
+		root = node
+		return false
+	}, nil)
+	return root, root != nil
+}
+
+// WriteDOT Writes tree to w as a Graphviz digraph.  Left and right edges leave from the bottom left and
+// bottom right of a node, and missing children are drawn as points so a lone child stays on its side.
+// Red black nodes are filled with their color.
+//
+// Render the output with, for example: dot -Tsvg tree.dot -o tree.svg
+func WriteDOT[E any](w io.Writer, tree Tree[E], options ...DOTOption[E]) error {
+	c := dotConfig[E]{name: "canopy"}
+	for _, o := range options {
+		o(&c)
+	}
+
+	root, ok := rootOf(tree)
+
+	highlighted := make(map[Node[E]]bool)
+	if ok && c.root {
+		highlighted[root] = true
+	}
+	if ok && len(c.path) > 0 {
+		ct, isComparable := tree.(interface{ comparator() func(a, b E) int })
+		if !isComparable || ct.comparator() == nil {
+			return ErrNoComparator
+		}
+		for _, value := range c.path {
+			searchPath(root, value, ct.comparator(), func(n Node[E]) {
+				highlighted[n] = true
+			})
+		}
+	}
+
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "digraph %s {\n", dotQuote(c.name))
+	b.WriteString("\tgraph [ordering=out];\n")
+	b.WriteString("\tnode [shape=circle];\n")
+
+	// ids are handed out as nodes are discovered, so a parent can write the edges to both of its children
+	// in order, left then right
+	type item struct {
+		n  Node[E]
+		id int
+	}
+
+	next := 0
+	stack := make([]item, 0)
+	if ok {
+		stack = append(stack, item{root, next})
+		next++
+	}
+	for len(stack) > 0 {
+		it := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		n := it.n
+
+		b.WriteString("\t" + dotNode(it.id, n, highlighted[n] || c.matches(n)) + ";\n")
+		if n.IsLeaf() {
+			continue
+		}
+
+		children := make([]item, 0, 2)
+		for _, child := range []struct {
+			get  func() (Node[E], bool)
+			port string
+		}{{n.Left, "sw"}, {n.Right, "se"}} {
+			id := next
+			next++
+			if cn, ok := child.get(); ok {
+				children = append(children, item{cn, id})
+				fmt.Fprintf(b, "\tn%d -> n%d [tailport=%s];\n", it.id, id, child.port)
+			} else {
+				fmt.Fprintf(b, "\tn%d [shape=point];\n\tn%d -> n%d [tailport=%s];\n", id, it.id, id, child.port)
+			}
+		}
+
+		// push right first so the left subtree is written first
+		for i := len(children) - 1; i >= 0; i-- {
+			stack = append(stack, children[i])
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.Flush()
+}
+
+func (c *dotConfig[E]) matches(n Node[E]) bool {
+	for _, h := range c.highlight {
+		if h(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// Calls visit on every node from root down to value, or down to the node value would hang from.
+func searchPath[E any](root Node[E], value E, compare func(a, b E) int, visit func(n Node[E])) {
+	n, ok := root, true
+	for ok {
+		visit(n)
+		x := compare(value, n.Value())
+		if x < 0 {
+			n, ok = n.Left()
+		} else if x > 0 {
+			n, ok = n.Right()
+		} else {
+			break
+		}
+	}
+}
+
+// Returns the DOT statement declaring n.
+func dotNode[E any](id int, n Node[E], highlight bool) string {
+	attrs := []string{"label=" + dotQuote(fmt.Sprint(n.Value()))}
+	if cn, ok := n.(ColoredNode[E]); ok {
+		if cn.Color() == Red {
+			attrs = append(attrs, "style=filled", "fillcolor=red")
+		} else {
+			attrs = append(attrs, "style=filled", "fillcolor=black", "fontcolor=white")
+		}
+	}
+	if highlight {
+		attrs = append(attrs, "color=blue", "penwidth=3")
+	}
+	return fmt.Sprintf("n%d [%s]", id, strings.Join(attrs, ", "))
+}
+
+// Returns s as a quoted DOT string.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,115 @@
+package canopy
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteDOT(t *testing.T) {
+	tree := NewBinarySearchTree[int]()
+	InsertAll[int](tree, 50, 30, 70, 60)
+
+	var b bytes.Buffer
+	if err := WriteDOT[int](&b, tree); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `digraph "canopy" {
+	graph [ordering=out];
+	node [shape=circle];
+	n0 [label="50"];
+	n0 -> n1 [tailport=sw];
+	n0 -> n2 [tailport=se];
+	n1 [label="30"];
+	n2 [label="70"];
+	n2 -> n3 [tailport=sw];
+	n4 [shape=point];
+	n2 -> n4 [tailport=se];
+	n3 [label="60"];
+}
+`
+	if b.String() != expected {
+		t.Errorf("unexpected output:\n%s", b.String())
+	}
+}
+
+func TestWriteDOTEmpty(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteDOT[int](&b, NewRedBlackTree[int](), DOTName[int]("empty")); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(b.String(), "label") || !strings.HasPrefix(b.String(), `digraph "empty" {`) {
+		t.Errorf("unexpected output:\n%s", b.String())
+	}
+}
+
+func TestWriteDOTRedBlack(t *testing.T) {
+	tree := NewRedBlackTree[int]()
+	InsertAll[int](tree, 50, 30, 70)
+
+	var b bytes.Buffer
+	if err := WriteDOT[int](&b, tree); err != nil {
+		t.Fatal(err)
+	}
+
+	out := b.String()
+	if !strings.Contains(out, `n0 [label="50", style=filled, fillcolor=black, fontcolor=white]`) {
+		t.Errorf("expected a black root:\n%s", out)
+	}
+	if strings.Count(out, "fillcolor=red") != 2 {
+		t.Errorf("expected two red nodes:\n%s", out)
+	}
+}
+
+// Returns the labels of the highlighted nodes in DOT output.
+func dotHighlighted(out string) []string {
+	highlighted := make([]string, 0)
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, "penwidth") {
+			highlighted = append(highlighted, line[strings.Index(line, `"`)+1:strings.LastIndex(line, `"`)])
+		}
+	}
+	return highlighted
+}
+
+func TestWriteDOTHighlight(t *testing.T) {
+	tree := NewBinarySearchTree[int]()
+	InsertAll[int](tree, 50, 30, 70, 20, 40, 60, 80)
+
+	var b bytes.Buffer
+	err := WriteDOT[int](&b, tree, HighlightPath[int](45), HighlightFunc(func(n Node[int]) bool {
+		return n.Value() == 80
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the search for 45 passes 50, 30 and 40
+	arrayEquals(t, "highlight", []string{"50", "30", "40", "80"}, dotHighlighted(b.String()))
+}
+
+func TestWriteDOTHighlightRoot(t *testing.T) {
+	tree := NewSplayTree[int]()
+	InsertAll[int](tree, 50, 30, 70, 20, 40, 60, 80)
+	tree.Find(40)
+
+	var b bytes.Buffer
+	if err := WriteDOT[int](&b, tree, HighlightRoot[int]()); err != nil {
+		t.Fatal(err)
+	}
+	arrayEquals(t, "highlight root", []string{"40"}, dotHighlighted(b.String()))
+}
+
+func TestWriteDOTQuote(t *testing.T) {
+	tree := NewBinarySearchTree[string]()
+	tree.Insert(`say "hi"`)
+
+	var b bytes.Buffer
+	if err := WriteDOT[string](&b, tree); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), `label="say \"hi\""`) {
+		t.Errorf("expected an escaped label:\n%s", b.String())
+	}
+}
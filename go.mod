@@ -1,3 +1,3 @@
 module github.com/jsx7ba/canopy
 
-go 1.22
+go 1.23
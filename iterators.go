@@ -0,0 +1,229 @@
+package canopy
+
+import (
+	"iter"
+)
+
+// traverser is implemented by every tree type.
+type traverser[E any] interface {
+	Traverse(method func(node Node[E], v func(node Node[E]) bool) bool, v func(node Node[E]) bool)
+}
+
+// Returns an iterator over the values visited by method.
+func traversalSeq[E any](t traverser[E], method func(node Node[E], v func(node Node[E]) bool) bool) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		visitor := func(n Node[E]) bool {
+			return yield(n.Value())
+		}
+		t.Traverse(method, visitor)
+	}
+}
+
+// Returns an iterator over the position and value of each node, in order.
+func indexedSeq[E any](t traverser[E]) iter.Seq2[int, E] {
+	return func(yield func(int, E) bool) {
+		i := 0
+		visitor := func(n Node[E]) bool {
+			if !yield(i, n.Value()) {
+				return false
+			}
+			i++
+			return true
+		}
+		t.Traverse(InOrder[E], visitor)
+	}
+}
+
+// Returns an iterator over the depth and value of each node, in order.  The root has a depth of zero.
+func depthSeq[E any](t traverser[E]) iter.Seq2[int, E] {
+	return func(yield func(int, E) bool) {
+		method := func(root Node[E], v func(node Node[E]) bool) bool {
+			return inOrderDepth(root, 0, func(depth int, n Node[E]) bool {
+				return yield(depth, n.Value())
+			})
+		}
+		t.Traverse(method, nil)
+	}
+}
+
+// Visit the nodes below node in order, along with their depth.
+func inOrderDepth[E any](node Node[E], depth int, v func(depth int, node Node[E]) bool) bool {
+	if left, ok := node.Left(); ok {
+		if !inOrderDepth(left, depth+1, v) {
+			return false
+		}
+	}
+
+	if !v(depth, node) {
+		return false
+	}
+
+	if right, ok := node.Right(); ok {
+		if !inOrderDepth(right, depth+1, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// All Returns an iterator over the values of the tree in ascending order.
+// The tree must not be modified during iteration.
+func (t *bsTree[E, V]) All() iter.Seq[E] {
+	return traversalSeq[E](t, InOrder[E])
+}
+
+// Backward Returns an iterator over the values of the tree in descending order.
+func (t *bsTree[E, V]) Backward() iter.Seq[E] {
+	return traversalSeq[E](t, ReverseInOrder[E])
+}
+
+// Descending Returns an iterator over the values of the tree in descending order, the same as Backward.
+func (t *bsTree[E, V]) Descending() iter.Seq[E] {
+	return t.Backward()
+}
+
+// Values Returns an iterator over the values of the tree in ascending order, the same as All.
+func (t *bsTree[E, V]) Values() iter.Seq[E] {
+	return t.All()
+}
+
+// Indexed Returns an iterator over the position and value of each element in ascending order.
+func (t *bsTree[E, V]) Indexed() iter.Seq2[int, E] {
+	return indexedSeq[E](t)
+}
+
+// Depths Returns an iterator over the depth and value of each element in ascending order.
+func (t *bsTree[E, V]) Depths() iter.Seq2[int, E] {
+	return depthSeq[E](t)
+}
+
+// All Returns an iterator over the values of the tree in ascending order.  Iterating does not splay.
+// The tree must not be modified during iteration.
+func (t *splayTree[E, V]) All() iter.Seq[E] {
+	return traversalSeq[E](t, InOrder[E])
+}
+
+// Backward Returns an iterator over the values of the tree in descending order.
+func (t *splayTree[E, V]) Backward() iter.Seq[E] {
+	return traversalSeq[E](t, ReverseInOrder[E])
+}
+
+// Descending Returns an iterator over the values of the tree in descending order, the same as Backward.
+func (t *splayTree[E, V]) Descending() iter.Seq[E] {
+	return t.Backward()
+}
+
+// Values Returns an iterator over the values of the tree in ascending order, the same as All.
+func (t *splayTree[E, V]) Values() iter.Seq[E] {
+	return t.All()
+}
+
+// Indexed Returns an iterator over the position and value of each element in ascending order.
+func (t *splayTree[E, V]) Indexed() iter.Seq2[int, E] {
+	return indexedSeq[E](t)
+}
+
+// Depths Returns an iterator over the depth and value of each element in ascending order.
+func (t *splayTree[E, V]) Depths() iter.Seq2[int, E] {
+	return depthSeq[E](t)
+}
+
+// All Returns an iterator over the values of the tree in ascending order.
+// The tree must not be modified during iteration.
+func (t *rbTree[E, V]) All() iter.Seq[E] {
+	return traversalSeq[E](t, InOrder[E])
+}
+
+// Backward Returns an iterator over the values of the tree in descending order.
+func (t *rbTree[E, V]) Backward() iter.Seq[E] {
+	return traversalSeq[E](t, ReverseInOrder[E])
+}
+
+// Descending Returns an iterator over the values of the tree in descending order, the same as Backward.
+func (t *rbTree[E, V]) Descending() iter.Seq[E] {
+	return t.Backward()
+}
+
+// Values Returns an iterator over the values of the tree in ascending order, the same as All.
+func (t *rbTree[E, V]) Values() iter.Seq[E] {
+	return t.All()
+}
+
+// Indexed Returns an iterator over the position and value of each element in ascending order.
+func (t *rbTree[E, V]) Indexed() iter.Seq2[int, E] {
+	return indexedSeq[E](t)
+}
+
+// Depths Returns an iterator over the depth and value of each element in ascending order.
+func (t *rbTree[E, V]) Depths() iter.Seq2[int, E] {
+	return depthSeq[E](t)
+}
+
+// All Returns an iterator over the values of the tree in ascending order.
+// The tree must not be modified during iteration.
+func (t *avlTree[E, V]) All() iter.Seq[E] {
+	return traversalSeq[E](t, InOrder[E])
+}
+
+// Backward Returns an iterator over the values of the tree in descending order.
+func (t *avlTree[E, V]) Backward() iter.Seq[E] {
+	return traversalSeq[E](t, ReverseInOrder[E])
+}
+
+// Descending Returns an iterator over the values of the tree in descending order, the same as Backward.
+func (t *avlTree[E, V]) Descending() iter.Seq[E] {
+	return t.Backward()
+}
+
+// Values Returns an iterator over the values of the tree in ascending order, the same as All.
+func (t *avlTree[E, V]) Values() iter.Seq[E] {
+	return t.All()
+}
+
+// Indexed Returns an iterator over the position and value of each element in ascending order.
+func (t *avlTree[E, V]) Indexed() iter.Seq2[int, E] {
+	return indexedSeq[E](t)
+}
+
+// Depths Returns an iterator over the depth and value of each element in ascending order.
+func (t *avlTree[E, V]) Depths() iter.Seq2[int, E] {
+	return depthSeq[E](t)
+}
+
+// All Returns an iterator over the entries of the map in key order.
+// The map must not be modified during iteration.
+func (m *TreeMap[K, V]) All() iter.Seq2[K, V] {
+	return m.entries(InOrder[K])
+}
+
+// Backward Returns an iterator over the entries of the map in descending key order.
+func (m *TreeMap[K, V]) Backward() iter.Seq2[K, V] {
+	return m.entries(ReverseInOrder[K])
+}
+
+// Keys Returns an iterator over the keys of the map in ascending order.
+func (m *TreeMap[K, V]) Keys() iter.Seq[K] {
+	return traversalSeq[K](m.tree, InOrder[K])
+}
+
+// Values Returns an iterator over the values of the map in key order.
+func (m *TreeMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (m *TreeMap[K, V]) entries(method func(node Node[K], v func(node Node[K]) bool) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		visitor := func(n Node[K]) bool {
+			entry := n.(MapNode[K, V])
+			return yield(entry.Key(), entry.MapValue())
+		}
+		m.tree.Traverse(method, visitor)
+	}
+}
@@ -0,0 +1,104 @@
+package canopy
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"testing"
+)
+
+// iterable is the part of a tree tested for the iterators.
+type iterable[E any] interface {
+	Tree[E]
+	All() iter.Seq[E]
+	Backward() iter.Seq[E]
+	Descending() iter.Seq[E]
+	Values() iter.Seq[E]
+	Indexed() iter.Seq2[int, E]
+	Depths() iter.Seq2[int, E]
+}
+
+func TestIterators(t *testing.T) {
+	data := []int{100, 20, 200, 10, 30, 150, 300}
+	ascending := []int{10, 20, 30, 100, 150, 200, 300}
+	descending := []int{300, 200, 150, 100, 30, 20, 10}
+
+	for name, tree := range treesAs[iterable[int]](t) {
+		InsertAll[int](tree, data...)
+		arrayEquals(t, name+" All", ascending, slices.Collect(tree.All()))
+		arrayEquals(t, name+" Values", ascending, slices.Collect(tree.Values()))
+		arrayEquals(t, name+" Backward", descending, slices.Collect(tree.Backward()))
+		arrayEquals(t, name+" Descending", descending, slices.Collect(tree.Descending()))
+
+		for i, v := range tree.Indexed() {
+			if ascending[i] != v {
+				t.Error(name, "expected", ascending[i], "at index", i, "got", v)
+			}
+		}
+	}
+}
+
+func TestIteratorBreak(t *testing.T) {
+	for name, tree := range treesAs[iterable[int]](t) {
+		InsertAll[int](tree, 5, 3, 8, 1, 4, 7, 9)
+
+		actual := make([]int, 0)
+		for v := range tree.All() {
+			if v > 4 {
+				break
+			}
+			actual = append(actual, v)
+		}
+		arrayEquals(t, name, []int{1, 3, 4}, actual)
+
+		actual = actual[:0]
+		for v := range tree.Backward() {
+			if v < 7 {
+				break
+			}
+			actual = append(actual, v)
+		}
+		arrayEquals(t, name, []int{9, 8, 7}, actual)
+	}
+}
+
+func TestIteratorDepths(t *testing.T) {
+	tree := NewBinarySearchTree[int]()
+	InsertAll(tree, 100, 20, 200, 10, 30, 150, 300)
+
+	expected := map[int]int{100: 0, 20: 1, 200: 1, 10: 2, 30: 2, 150: 2, 300: 2}
+	for depth, v := range tree.Depths() {
+		if expected[v] != depth {
+			t.Error("expected", v, "at depth", expected[v], "got", depth)
+		}
+	}
+}
+
+func TestIteratorEmpty(t *testing.T) {
+	for name, tree := range treesAs[iterable[int]](t) {
+		for range tree.All() {
+			t.Error(name, "iterated over an empty tree")
+		}
+	}
+}
+
+func TestTreeMapIterators(t *testing.T) {
+	m := NewAVLTreeMap[string, int]()
+	m.Put("b", 2)
+	m.Put("c", 3)
+	m.Put("a", 1)
+
+	arrayEquals(t, "Keys", []string{"a", "b", "c"}, slices.Collect(m.Keys()))
+	arrayEquals(t, "Values", []int{1, 2, 3}, slices.Collect(m.Values()))
+
+	keys := make([]string, 0)
+	for k := range m.Backward() {
+		keys = append(keys, k)
+	}
+	arrayEquals(t, "Backward", []string{"c", "b", "a"}, keys)
+
+	collected := maps.Collect(m.All())
+	if len(collected) != 3 || collected["b"] != 2 {
+		t.Error("unexpected entries", collected)
+	}
+}
@@ -0,0 +1,269 @@
+package canopy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ShapedJSON marshals a tree to JSON as nested objects which preserve the exact shape of the tree:
+//
+//	{"value": 50, "color": "black", "left": {"value": 30, "color": "red"}}
+//
+// The color is only written for red black trees.  An empty tree is written as null.  Unmarshalling a
+// ShapedJSON unmarshals into Tree, which must be one of the trees in this package.
+//
+// Without ShapedJSON a tree marshals to the compact form, an array of its values in ascending order.  A
+// tree unmarshals from either form, an array is always loaded into a balanced tree.  A zero value tree,
+// such as a field of a struct being unmarshalled, is ordered by the natural order of its element type when
+// the element type is a number or a string.
+//
+// The nested form of a degenerate tree deeper than the nesting limit of encoding/json can be written but
+// not read back.
+type ShapedJSON[E any] struct {
+	Tree Tree[E]
+}
+
+// jsonNode is a node of the nested form.
+type jsonNode[E any] struct {
+	Value E            `json:"value"`
+	Color string       `json:"color,omitempty"`
+	Left  *jsonNode[E] `json:"left,omitempty"`
+	Right *jsonNode[E] `json:"right,omitempty"`
+}
+
+func (s ShapedJSON[E]) MarshalJSON() ([]byte, error) {
+	root, ok := rootOf(s.Tree)
+	if !ok {
+		return []byte("null"), nil
+	}
+	return json.Marshal(shapeOf(root))
+}
+
+func (s *ShapedJSON[E]) UnmarshalJSON(data []byte) error {
+	u, ok := s.Tree.(json.Unmarshaler)
+	if !ok {
+		return fmt.Errorf("canopy: cannot unmarshal into %T", s.Tree)
+	}
+	return u.UnmarshalJSON(data)
+}
+
+// Returns the nested form of the subtree below n.
+func shapeOf[E any](n Node[E]) *jsonNode[E] {
+	j := &jsonNode[E]{Value: n.Value()}
+	if cn, ok := n.(ColoredNode[E]); ok {
+		j.Color = cn.Color().String()
+	}
+	if left, ok := n.Left(); ok {
+		j.Left = shapeOf(left)
+	}
+	if right, ok := n.Right(); ok {
+		j.Right = shapeOf(right)
+	}
+	return j
+}
+
+// snapshotTree is the part of every tree type the encodings use to save and restore it.
+type snapshotTree[E any] interface {
+	traverser[E]
+	Len() int
+	comparator() func(a, b E) int
+
+	// replace the contents of the tree with strictly ascending values
+	build(sorted []E)
+}
+
+// shapedTree is a snapshotTree which can also restore the nested form.
+type shapedTree[E any] interface {
+	snapshotTree[E]
+
+	// replace the contents of the tree with the nested form, leaving the tree unchanged if the nested form
+	// isn't a valid tree
+	loadShape(shape *jsonNode[E]) error
+}
+
+// Returns the values of t in ascending order as a JSON array.
+func marshalJSON[E any](t snapshotTree[E]) ([]byte, error) {
+	values := make([]E, 0, t.Len())
+	t.Traverse(InOrder[E], func(n Node[E]) bool {
+		values = append(values, n.Value())
+		return true
+	})
+	return json.Marshal(values)
+}
+
+// Replaces the contents of t with data in either JSON form.  An array is loaded balanced, an object as
+// the nested form and null empties the tree.
+func unmarshalJSON[E any](t shapedTree[E], data []byte) error {
+	compare := t.comparator()
+	if compare == nil {
+		return ErrNoComparator
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var values []E
+		if err := json.Unmarshal(data, &values); err != nil {
+			return err
+		}
+		t.build(sortedUnique(values, compare))
+		return nil
+	}
+
+	var shape *jsonNode[E]
+	if err := json.Unmarshal(data, &shape); err != nil {
+		return err
+	}
+	if shape == nil {
+		t.build(nil)
+		return nil
+	}
+	return t.loadShape(shape)
+}
+
+// Returns the color of a node in the nested form.
+func parseColor(color string) (Color, error) {
+	switch color {
+	case "red":
+		return Red, nil
+	case "black":
+		return Black, nil
+	}
+	return Black, fmt.Errorf("canopy: unknown color %q", color)
+}
+
+// Build a subtree of bsNodes with the shape of j.
+func shapeBS[E, V any](j *jsonNode[E], parent *bsNode[E, V]) *bsNode[E, V] {
+	if j == nil {
+		return nil
+	}
+
+	n := &bsNode[E, V]{value: j.Value, parent: parent}
+	n.left = shapeBS(j.Left, n)
+	n.right = shapeBS(j.Right, n)
+	n.update()
+	return n
+}
+
+// Build a subtree of rbNodes with the shape and colors of j.
+func shapeRB[E, V any](j *jsonNode[E], parent *rbNode[E, V]) (*rbNode[E, V], error) {
+	if j == nil {
+		return nil, nil
+	}
+
+	color, err := parseColor(j.Color)
+	if err != nil {
+		return nil, fmt.Errorf("%w for %v", err, j.Value)
+	}
+
+	n := &rbNode[E, V]{value: j.Value, parent: parent, color: color}
+	if n.left, err = shapeRB(j.Left, n); err != nil {
+		return nil, err
+	}
+	if n.right, err = shapeRB(j.Right, n); err != nil {
+		return nil, err
+	}
+	n.update()
+	return n, nil
+}
+
+// Build a subtree of avlNodes with the shape of j.
+func shapeAVL[E, V any](j *jsonNode[E], parent *avlNode[E, V]) *avlNode[E, V] {
+	if j == nil {
+		return nil
+	}
+
+	n := &avlNode[E, V]{value: j.Value, parent: parent}
+	n.left = shapeAVL(j.Left, n)
+	n.right = shapeAVL(j.Right, n)
+	n.update()
+	return n
+}
+
+// MarshalJSON Returns the values of the tree in ascending order as a JSON array.
+func (t *bsTree[E, V]) MarshalJSON() ([]byte, error) {
+	return marshalJSON[E](t)
+}
+
+// UnmarshalJSON Replaces the contents of the tree with a JSON array of values, or with the nested form
+// written by ShapedJSON.
+func (t *bsTree[E, V]) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON[E](t, data)
+}
+
+func (t *bsTree[E, V]) loadShape(shape *jsonNode[E]) error {
+	root := shapeBS[E, V](shape, nil)
+	u := bsTree[E, V]{root: root, compare: t.comparator(), size: root.count()}
+	if err := u.Validate(); err != nil {
+		return err
+	}
+	t.root, t.compare, t.size = u.root, u.compare, u.size
+	return nil
+}
+
+// MarshalJSON Returns the values of the tree in ascending order as a JSON array.
+func (t *splayTree[E, V]) MarshalJSON() ([]byte, error) {
+	return marshalJSON[E](t)
+}
+
+// UnmarshalJSON Replaces the contents of the tree with a JSON array of values, or with the nested form
+// written by ShapedJSON.
+func (t *splayTree[E, V]) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON[E](t, data)
+}
+
+func (t *splayTree[E, V]) loadShape(shape *jsonNode[E]) error {
+	root := shapeBS[E, V](shape, nil)
+	u := splayTree[E, V]{root: root, compare: t.comparator(), size: root.count()}
+	if err := u.Validate(); err != nil {
+		return err
+	}
+	t.root, t.compare, t.size = u.root, u.compare, u.size
+	return nil
+}
+
+// MarshalJSON Returns the values of the tree in ascending order as a JSON array.
+func (t *rbTree[E, V]) MarshalJSON() ([]byte, error) {
+	return marshalJSON[E](t)
+}
+
+// UnmarshalJSON Replaces the contents of the tree with a JSON array of values, or with the nested form
+// written by ShapedJSON.  Every node of the nested form must have a color.
+func (t *rbTree[E, V]) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON[E](t, data)
+}
+
+func (t *rbTree[E, V]) loadShape(shape *jsonNode[E]) error {
+	root, err := shapeRB[E, V](shape, nil)
+	if err != nil {
+		return err
+	}
+
+	u := rbTree[E, V]{root: root, compare: t.comparator(), size: root.count()}
+	if err := u.Validate(); err != nil {
+		return err
+	}
+	t.root, t.compare, t.size = u.root, u.compare, u.size
+	return nil
+}
+
+// MarshalJSON Returns the values of the tree in ascending order as a JSON array.
+func (t *avlTree[E, V]) MarshalJSON() ([]byte, error) {
+	return marshalJSON[E](t)
+}
+
+// UnmarshalJSON Replaces the contents of the tree with a JSON array of values, or with the nested form
+// written by ShapedJSON.  The nested form must be height balanced.
+func (t *avlTree[E, V]) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON[E](t, data)
+}
+
+func (t *avlTree[E, V]) loadShape(shape *jsonNode[E]) error {
+	root := shapeAVL[E, V](shape, nil)
+	u := avlTree[E, V]{root: root, compare: t.comparator(), size: root.count()}
+	if err := u.Validate(); err != nil {
+		return err
+	}
+	t.root, t.compare, t.size = u.root, u.compare, u.size
+	return nil
+}
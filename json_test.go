@@ -0,0 +1,160 @@
+package canopy
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+// jsonTree is the part of a tree tested for the JSON encoding.
+type jsonTree[E any] interface {
+	validTree[E]
+	json.Marshaler
+	json.Unmarshaler
+}
+
+// Returns the values of tree in pre-order, which identifies the shape of the tree.
+func preOrderValues[E any](tree Tree[E]) []E {
+	values := make([]E, 0)
+	tree.Traverse(PreOrder[E], func(n Node[E]) bool {
+		values = append(values, n.Value())
+		return true
+	})
+	return values
+}
+
+func TestMarshalJSON(t *testing.T) {
+	for name, tree := range treesAs[jsonTree[int]](t) {
+		data, err := json.Marshal(tree)
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		if string(data) != "[]" {
+			t.Error(name, "expected an empty array, got", string(data))
+		}
+
+		InsertAll[int](tree, 50, 30, 70, 20, 40)
+		data, err = json.Marshal(tree)
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		if string(data) != "[20,30,40,50,70]" {
+			t.Error(name, "unexpected JSON", string(data))
+		}
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	for name, tree := range treesAs[jsonTree[int]](t) {
+		tree.Insert(1000)
+		if err := json.Unmarshal([]byte("[9, 3, 7, 1, 3, 5, 2, 8, 4, 6]"), tree); err != nil {
+			t.Fatal(name, err)
+		}
+		if err := tree.Validate(); err != nil {
+			t.Error(name, err)
+		}
+		arrayEquals(t, name, []int{5, 3, 2, 1, 4, 8, 7, 6, 9}, preOrderValues[int](tree))
+
+		if err := json.Unmarshal([]byte("null"), tree); err != nil || !tree.IsEmpty() {
+			t.Error(name, "expected null to empty the tree", err)
+		}
+	}
+}
+
+func TestShapedJSON(t *testing.T) {
+	for name, tree := range treesAs[jsonTree[int]](t) {
+		InsertAll[int](tree, 10, 20, 30, 40, 50, 60, 70)
+		expected := preOrderValues[int](tree)
+
+		data, err := json.Marshal(ShapedJSON[int]{tree})
+		if err != nil {
+			t.Fatal(name, err)
+		}
+
+		for other, copied := range treesAs[jsonTree[int]](t) {
+			if err := json.Unmarshal(data, &ShapedJSON[int]{copied}); err != nil {
+				// an unbalanced shape is not a valid AVL tree, and only red black shapes have colors
+				if other != "avl" && other != "redblack" {
+					t.Error(name, "to", other, err)
+				}
+				continue
+			}
+			arrayEquals(t, name+" to "+other, expected, preOrderValues[int](copied))
+			if err := copied.Validate(); err != nil {
+				t.Error(name, "to", other, err)
+			}
+		}
+
+		copied := treesAs[jsonTree[int]](t)[name]
+		if err := json.Unmarshal(data, copied); err != nil {
+			t.Fatal(name, err)
+		}
+		arrayEquals(t, name, expected, preOrderValues[int](copied))
+	}
+}
+
+func TestShapedJSONRedBlack(t *testing.T) {
+	tree := NewRedBlackTree[int]()
+	InsertAll[int](tree, 50, 30, 70, 20)
+
+	data, err := json.Marshal(ShapedJSON[int]{tree})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"value":50,"color":"black","left":{"value":30,"color":"black","left":{"value":20,"color":"red"}},"right":{"value":70,"color":"black"}}`
+	if string(data) != expected {
+		t.Error("unexpected JSON", string(data))
+	}
+
+	data, err = json.Marshal(ShapedJSON[int]{NewRedBlackTree[int]()})
+	if err != nil || string(data) != "null" {
+		t.Error("expected null for an empty tree, got", string(data), err)
+	}
+}
+
+func TestUnmarshalJSONInvalid(t *testing.T) {
+	invalid := map[string]string{
+		"order":     `{"value":50,"color":"black","left":{"value":60,"color":"red"}}`,
+		"red root":  `{"value":50,"color":"red"}`,
+		"red red":   `{"value":50,"color":"black","left":{"value":30,"color":"red","left":{"value":20,"color":"red"}}}`,
+		"no color":  `{"value":50}`,
+		"bad color": `{"value":50,"color":"blue"}`,
+		"syntax":    `[1, 2`,
+	}
+
+	for name, data := range invalid {
+		tree := NewRedBlackTree[int]()
+		InsertAll[int](tree, 1, 2, 3)
+		if err := json.Unmarshal([]byte(data), tree); err == nil {
+			t.Error(name, "expected an error")
+		}
+		arrayEquals(t, name, []int{1, 2, 3}, slices.Collect(tree.All()))
+	}
+
+	// an AVL tree must be balanced
+	tree := NewAVLTree[int]()
+	if err := json.Unmarshal([]byte(`{"value":1,"right":{"value":2,"right":{"value":3}}}`), tree); err == nil {
+		t.Error("expected an error for an unbalanced AVL tree")
+	}
+}
+
+func TestUnmarshalJSONZeroValue(t *testing.T) {
+	var config struct {
+		Names *RedBlackTree[string] `json:"names"`
+		IDs   AVLTree[uint16]       `json:"ids"`
+	}
+
+	err := json.Unmarshal([]byte(`{"names": ["carol", "alice", "bob"], "ids": [300, 20, 1]}`), &config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.Names.Insert("dave")
+	arrayEquals(t, "names", []string{"alice", "bob", "carol", "dave"}, slices.Collect(config.Names.All()))
+	arrayEquals(t, "ids", []uint16{1, 20, 300}, slices.Collect(config.IDs.All()))
+
+	var points BSTree[struct{ X, Y int }]
+	if err := json.Unmarshal([]byte(`[{"X": 1, "Y": 2}]`), &points); err != ErrNoComparator {
+		t.Error("expected ErrNoComparator, got", err)
+	}
+}
@@ -0,0 +1,110 @@
+package canopy
+
+// Returns the node end finds from the root of t, such as leftmost or rightmost, or nil if t is empty.
+func endNode[E any](t searchTree[E], end func(n Node[E]) Node[E]) Node[E] {
+	root, ok := t.rootNode()
+	if !ok {
+		return nil
+	}
+	return end(root)
+}
+
+// Removes and returns the value of the node end finds from the root of t, and false if t is empty.
+func popEnd[E any](t searchTree[E], end func(n Node[E]) Node[E]) (E, bool) {
+	value, ok := nodeValue(endNode(t, end))
+	if ok {
+		t.removeValue(value)
+	}
+	return value, ok
+}
+
+// Min Returns the smallest value in the tree, and false if the tree is empty.
+func (t *bsTree[E, V]) Min() (E, bool) {
+	return nodeValue(endNode[E](t, leftmost[E]))
+}
+
+// Max Returns the largest value in the tree, and false if the tree is empty.
+func (t *bsTree[E, V]) Max() (E, bool) {
+	return nodeValue(endNode[E](t, rightmost[E]))
+}
+
+// PopMin Removes and returns the smallest value in the tree, and false if the tree is empty.
+func (t *bsTree[E, V]) PopMin() (E, bool) {
+	return popEnd[E](t, leftmost[E])
+}
+
+// PopMax Removes and returns the largest value in the tree, and false if the tree is empty.
+func (t *bsTree[E, V]) PopMax() (E, bool) {
+	return popEnd[E](t, rightmost[E])
+}
+
+// Returns the value of the node end finds, splaying that node to the root.
+func (t *splayTree[E, V]) splayEnd(end func(n Node[E]) Node[E]) (E, bool) {
+	n := endNode[E](t, end)
+	if n != nil {
+		t.splay(n.(*bsNode[E, V]))
+	}
+	return nodeValue(n)
+}
+
+// Min Returns the smallest value in the tree, and false if the tree is empty.
+// The smallest bsNode is splayed to the root.
+func (t *splayTree[E, V]) Min() (E, bool) {
+	return t.splayEnd(leftmost[E])
+}
+
+// Max Returns the largest value in the tree, and false if the tree is empty.
+// The largest bsNode is splayed to the root.
+func (t *splayTree[E, V]) Max() (E, bool) {
+	return t.splayEnd(rightmost[E])
+}
+
+// PopMin Removes and returns the smallest value in the tree, and false if the tree is empty.
+func (t *splayTree[E, V]) PopMin() (E, bool) {
+	return popEnd[E](t, leftmost[E])
+}
+
+// PopMax Removes and returns the largest value in the tree, and false if the tree is empty.
+func (t *splayTree[E, V]) PopMax() (E, bool) {
+	return popEnd[E](t, rightmost[E])
+}
+
+// Min Returns the smallest value in the tree, and false if the tree is empty.
+func (t *rbTree[E, V]) Min() (E, bool) {
+	return nodeValue(endNode[E](t, leftmost[E]))
+}
+
+// Max Returns the largest value in the tree, and false if the tree is empty.
+func (t *rbTree[E, V]) Max() (E, bool) {
+	return nodeValue(endNode[E](t, rightmost[E]))
+}
+
+// PopMin Removes and returns the smallest value in the tree, and false if the tree is empty.
+func (t *rbTree[E, V]) PopMin() (E, bool) {
+	return popEnd[E](t, leftmost[E])
+}
+
+// PopMax Removes and returns the largest value in the tree, and false if the tree is empty.
+func (t *rbTree[E, V]) PopMax() (E, bool) {
+	return popEnd[E](t, rightmost[E])
+}
+
+// Min Returns the smallest value in the tree, and false if the tree is empty.
+func (t *avlTree[E, V]) Min() (E, bool) {
+	return nodeValue(endNode[E](t, leftmost[E]))
+}
+
+// Max Returns the largest value in the tree, and false if the tree is empty.
+func (t *avlTree[E, V]) Max() (E, bool) {
+	return nodeValue(endNode[E](t, rightmost[E]))
+}
+
+// PopMin Removes and returns the smallest value in the tree, and false if the tree is empty.
+func (t *avlTree[E, V]) PopMin() (E, bool) {
+	return popEnd[E](t, leftmost[E])
+}
+
+// PopMax Removes and returns the largest value in the tree, and false if the tree is empty.
+func (t *avlTree[E, V]) PopMax() (E, bool) {
+	return popEnd[E](t, rightmost[E])
+}
@@ -0,0 +1,73 @@
+package canopy
+
+import (
+	"testing"
+)
+
+// minMaxTree is the part of a tree tested for the smallest and largest value queries.
+type minMaxTree[E any] interface {
+	Tree[E]
+	Min() (E, bool)
+	Max() (E, bool)
+	PopMin() (E, bool)
+	PopMax() (E, bool)
+}
+
+func TestMinMax(t *testing.T) {
+	for name, tree := range treesAs[minMaxTree[int]](t) {
+		InsertAll[int](tree, 30, 10, 50, 20, 40)
+
+		if v, ok := tree.Min(); !ok || v != 10 {
+			t.Error(name, "expected min 10, got", v, ok)
+		}
+		if v, ok := tree.Max(); !ok || v != 50 {
+			t.Error(name, "expected max 50, got", v, ok)
+		}
+		if !tree.Find(10) || !tree.Find(50) {
+			t.Error(name, "Min or Max removed a value")
+		}
+	}
+}
+
+func TestPopMinMax(t *testing.T) {
+	for name, tree := range treesAs[minMaxTree[int]](t) {
+		InsertAll[int](tree, 30, 10, 50, 20, 40, 60)
+
+		actual := make([]int, 0)
+		for {
+			low, ok := tree.PopMin()
+			if !ok {
+				break
+			}
+			actual = append(actual, low)
+
+			high, ok := tree.PopMax()
+			if !ok {
+				break
+			}
+			actual = append(actual, high)
+		}
+		arrayEquals(t, name, []int{10, 60, 20, 50, 30, 40}, actual)
+
+		if _, ok := tree.Min(); ok {
+			t.Error(name, "expected an empty tree")
+		}
+		if _, ok := tree.PopMax(); ok {
+			t.Error(name, "popped from an empty tree")
+		}
+	}
+}
+
+func TestPopMinRedBlack(t *testing.T) {
+	tree := NewRedBlackTree[int]()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i)
+	}
+
+	for i := 0; i < 100; i++ {
+		if v, ok := tree.PopMin(); !ok || v != i {
+			t.Fatal("expected", i, "got", v, ok)
+		}
+		checkRedBlack(t, tree)
+	}
+}
@@ -0,0 +1,172 @@
+package canopy
+
+// Search below root for the closest value to value.  When below is true the result is less than value,
+// otherwise it's greater than value.  An equal value is accepted when inclusive is true.
+// Returns the matching node or nil, and the last node visited.
+func nearest[E any](root Node[E], value E, compare func(a, b E) int, below, inclusive bool) (Node[E], Node[E]) {
+	var match Node[E]
+	n, ok := root, true
+	last := root
+	for ok {
+		last = n
+		x := compare(n.Value(), value)
+		if x == 0 && inclusive {
+			return n, n
+		}
+
+		if below {
+			if x < 0 {
+				match = n
+				n, ok = n.Right()
+			} else {
+				n, ok = n.Left()
+			}
+		} else {
+			if x > 0 {
+				match = n
+				n, ok = n.Left()
+			} else {
+				n, ok = n.Right()
+			}
+		}
+	}
+	return match, last
+}
+
+func nodeValue[E any](n Node[E]) (E, bool) {
+	if n == nil {
+		var zero E
+		return zero, false
+	}
+	return n.Value(), true
+}
+
+func (t *bsTree[E, V]) nearest(value E, below, inclusive bool) (E, bool) {
+	if t.root == nil {
+		var zero E
+		return zero, false
+	}
+
+	match, _ := nearest[E](t.root, value, t.compare, below, inclusive)
+	return nodeValue(match)
+}
+
+// Floor Returns the greatest value less than or equal to value, and false if there isn't one.
+func (t *bsTree[E, V]) Floor(value E) (E, bool) {
+	return t.nearest(value, true, true)
+}
+
+// Ceiling Returns the smallest value greater than or equal to value, and false if there isn't one.
+func (t *bsTree[E, V]) Ceiling(value E) (E, bool) {
+	return t.nearest(value, false, true)
+}
+
+// Lower Returns the greatest value strictly less than value, and false if there isn't one.
+func (t *bsTree[E, V]) Lower(value E) (E, bool) {
+	return t.nearest(value, true, false)
+}
+
+// Higher Returns the smallest value strictly greater than value, and false if there isn't one.
+func (t *bsTree[E, V]) Higher(value E) (E, bool) {
+	return t.nearest(value, false, false)
+}
+
+// The matching bsNode is splayed to the root, or the last bsNode visited if there is no match.
+func (t *splayTree[E, V]) nearest(value E, below, inclusive bool) (E, bool) {
+	if t.root == nil {
+		var zero E
+		return zero, false
+	}
+
+	match, last := nearest[E](t.root, value, t.compare, below, inclusive)
+	if match != nil {
+		t.splay(match.(*bsNode[E, V]))
+	} else {
+		t.splay(last.(*bsNode[E, V]))
+	}
+	return nodeValue(match)
+}
+
+// Floor Returns the greatest value less than or equal to value, and false if there isn't one.
+// The tree splays on the bsNode found.
+func (t *splayTree[E, V]) Floor(value E) (E, bool) {
+	return t.nearest(value, true, true)
+}
+
+// Ceiling Returns the smallest value greater than or equal to value, and false if there isn't one.
+// The tree splays on the bsNode found.
+func (t *splayTree[E, V]) Ceiling(value E) (E, bool) {
+	return t.nearest(value, false, true)
+}
+
+// Lower Returns the greatest value strictly less than value, and false if there isn't one.
+// The tree splays on the bsNode found.
+func (t *splayTree[E, V]) Lower(value E) (E, bool) {
+	return t.nearest(value, true, false)
+}
+
+// Higher Returns the smallest value strictly greater than value, and false if there isn't one.
+// The tree splays on the bsNode found.
+func (t *splayTree[E, V]) Higher(value E) (E, bool) {
+	return t.nearest(value, false, false)
+}
+
+func (t *rbTree[E, V]) nearest(value E, below, inclusive bool) (E, bool) {
+	if t.root == nil {
+		var zero E
+		return zero, false
+	}
+
+	match, _ := nearest[E](t.root, value, t.compare, below, inclusive)
+	return nodeValue(match)
+}
+
+// Floor Returns the greatest value less than or equal to value, and false if there isn't one.
+func (t *rbTree[E, V]) Floor(value E) (E, bool) {
+	return t.nearest(value, true, true)
+}
+
+// Ceiling Returns the smallest value greater than or equal to value, and false if there isn't one.
+func (t *rbTree[E, V]) Ceiling(value E) (E, bool) {
+	return t.nearest(value, false, true)
+}
+
+// Lower Returns the greatest value strictly less than value, and false if there isn't one.
+func (t *rbTree[E, V]) Lower(value E) (E, bool) {
+	return t.nearest(value, true, false)
+}
+
+// Higher Returns the smallest value strictly greater than value, and false if there isn't one.
+func (t *rbTree[E, V]) Higher(value E) (E, bool) {
+	return t.nearest(value, false, false)
+}
+
+func (t *avlTree[E, V]) nearest(value E, below, inclusive bool) (E, bool) {
+	if t.root == nil {
+		var zero E
+		return zero, false
+	}
+
+	match, _ := nearest[E](t.root, value, t.compare, below, inclusive)
+	return nodeValue(match)
+}
+
+// Floor Returns the greatest value less than or equal to value, and false if there isn't one.
+func (t *avlTree[E, V]) Floor(value E) (E, bool) {
+	return t.nearest(value, true, true)
+}
+
+// Ceiling Returns the smallest value greater than or equal to value, and false if there isn't one.
+func (t *avlTree[E, V]) Ceiling(value E) (E, bool) {
+	return t.nearest(value, false, true)
+}
+
+// Lower Returns the greatest value strictly less than value, and false if there isn't one.
+func (t *avlTree[E, V]) Lower(value E) (E, bool) {
+	return t.nearest(value, true, false)
+}
+
+// Higher Returns the smallest value strictly greater than value, and false if there isn't one.
+func (t *avlTree[E, V]) Higher(value E) (E, bool) {
+	return t.nearest(value, false, false)
+}
@@ -0,0 +1,86 @@
+package canopy
+
+import (
+	"testing"
+)
+
+// nearestTree is the part of a tree tested for the nearest value queries.
+type nearestTree[E any] interface {
+	Tree[E]
+	Floor(value E) (E, bool)
+	Ceiling(value E) (E, bool)
+	Lower(value E) (E, bool)
+	Higher(value E) (E, bool)
+}
+
+func TestNearest(t *testing.T) {
+	type data struct {
+		query   int
+		floor   int
+		ceiling int
+		lower   int
+		higher  int
+	}
+
+	// -1 means no result is expected
+	testData := []data{
+		{5, -1, 10, -1, 10},
+		{10, 10, 10, -1, 20},
+		{25, 20, 30, 20, 30},
+		{30, 30, 30, 20, 40},
+		{50, 50, 50, 40, -1},
+		{55, 50, -1, 50, -1},
+	}
+
+	for name, tree := range treesAs[nearestTree[int]](t) {
+		InsertAll[int](tree, 30, 10, 50, 20, 40)
+		for _, d := range testData {
+			checkNearest(t, name+" Floor", d.query, d.floor, tree.Floor)
+			checkNearest(t, name+" Ceiling", d.query, d.ceiling, tree.Ceiling)
+			checkNearest(t, name+" Lower", d.query, d.lower, tree.Lower)
+			checkNearest(t, name+" Higher", d.query, d.higher, tree.Higher)
+		}
+	}
+}
+
+func checkNearest(t *testing.T, prefix string, query, expected int, f func(int) (int, bool)) {
+	t.Helper()
+	actual, ok := f(query)
+	if expected == -1 {
+		if ok {
+			t.Error(prefix, "of", query, "expected no result, got", actual)
+		}
+		return
+	}
+
+	if !ok || actual != expected {
+		t.Error(prefix, "of", query, "expected", expected, "got", actual, ok)
+	}
+}
+
+func TestNearestEmpty(t *testing.T) {
+	for name, tree := range treesAs[nearestTree[int]](t) {
+		if _, ok := tree.Floor(1); ok {
+			t.Error(name, "found a floor in an empty tree")
+		}
+		if _, ok := tree.Higher(1); ok {
+			t.Error(name, "found a higher value in an empty tree")
+		}
+	}
+}
+
+func TestNearestSplays(t *testing.T) {
+	tree := NewSplayTree[int]()
+	InsertAll(tree, 30, 10, 50, 20, 40)
+
+	tree.Floor(25)
+	if tree.root.value != 20 {
+		t.Error("expected 20 to be splayed to the root, got", tree.root.value)
+	}
+
+	// no match, the last bsNode visited is splayed
+	tree.Higher(60)
+	if tree.root.value != 50 {
+		t.Error("expected 50 to be splayed to the root, got", tree.root.value)
+	}
+}
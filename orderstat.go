@@ -0,0 +1,121 @@
+package canopy
+
+// Returns the node holding the k-th smallest value below n, counting from zero, or nil if k is out of range.
+func selectNode[E any](n Node[E], k int) Node[E] {
+	if k < 0 || k >= subtreeSize(n) {
+		return nil
+	}
+
+	for {
+		left, ok := n.Left()
+		leftCount := 0
+		if ok {
+			leftCount = subtreeSize(left)
+		}
+
+		if k < leftCount {
+			n = left
+		} else if k == leftCount {
+			return n
+		} else {
+			k -= leftCount + 1
+			n, _ = n.Right()
+		}
+	}
+}
+
+// Returns the number of values below n that are less than value, and the last node visited.
+func rankOf[E any](n Node[E], value E, compare func(a, b E) int) (int, Node[E]) {
+	rank := 0
+	last := n
+	for ok := true; ok; {
+		last = n
+		left, hasLeft := n.Left()
+		x := compare(value, n.Value())
+		if x <= 0 {
+			if x == 0 && hasLeft {
+				rank += subtreeSize(left)
+				break
+			}
+			n, ok = left, hasLeft
+		} else {
+			rank++
+			if hasLeft {
+				rank += subtreeSize(left)
+			}
+			n, ok = n.Right()
+		}
+	}
+	return rank, last
+}
+
+// Returns the node of t holding the k-th smallest value, or nil if k is out of range.
+func selectIn[E any](t searchTree[E], k int) Node[E] {
+	root, ok := t.rootNode()
+	if !ok {
+		return nil
+	}
+	return selectNode(root, k)
+}
+
+// Returns the number of values of t that are less than value, and the last node visited or nil if t is empty.
+func rankIn[E any](t searchTree[E], value E) (int, Node[E]) {
+	root, ok := t.rootNode()
+	if !ok {
+		return 0, nil
+	}
+	return rankOf(root, value, t.comparator())
+}
+
+// Select Returns the k-th smallest value in the tree, counting from zero, and false if k is out of range.
+func (t *bsTree[E, V]) Select(k int) (E, bool) {
+	return nodeValue(selectIn[E](t, k))
+}
+
+// Rank Returns the number of values in the tree that are less than value.
+func (t *bsTree[E, V]) Rank(value E) int {
+	rank, _ := rankIn[E](t, value)
+	return rank
+}
+
+// Select Returns the k-th smallest value in the tree, counting from zero, and false if k is out of range.
+// The selected bsNode is splayed to the root.
+func (t *splayTree[E, V]) Select(k int) (E, bool) {
+	n := selectIn[E](t, k)
+	if n != nil {
+		t.splay(n.(*bsNode[E, V]))
+	}
+	return nodeValue(n)
+}
+
+// Rank Returns the number of values in the tree that are less than value.
+// The last bsNode visited is splayed to the root.
+func (t *splayTree[E, V]) Rank(value E) int {
+	rank, last := rankIn[E](t, value)
+	if last != nil {
+		t.splay(last.(*bsNode[E, V]))
+	}
+	return rank
+}
+
+// Select Returns the k-th smallest value in the tree, counting from zero, and false if k is out of range.
+func (t *rbTree[E, V]) Select(k int) (E, bool) {
+	return nodeValue(selectIn[E](t, k))
+}
+
+// Rank Returns the number of values in the tree that are less than value.
+func (t *rbTree[E, V]) Rank(value E) int {
+	rank, _ := rankIn[E](t, value)
+	return rank
+}
+
+// Select Returns the k-th smallest value in the tree, counting from zero, and false if k is out of range.
+func (t *avlTree[E, V]) Select(k int) (E, bool) {
+	return nodeValue(selectIn[E](t, k))
+}
+
+// Rank Returns the number of values in the tree that are less than value.
+func (t *avlTree[E, V]) Rank(value E) int {
+	rank, _ := rankIn[E](t, value)
+	return rank
+}
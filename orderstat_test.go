@@ -0,0 +1,86 @@
+package canopy
+
+import (
+	"testing"
+)
+
+// orderStatTree is the part of a tree tested for the order statistics.
+type orderStatTree[E any] interface {
+	Tree[E]
+	Select(k int) (E, bool)
+	Rank(value E) int
+}
+
+var orderStatValues = []int{
+	3, 36, 93, 61, 23, 83, 6, 25, 13, 66,
+	39, 63, 30, 20, 19, 21, 78, 72, 46, 40,
+	92, 84, 47, 24, 58, 89, 96, 26, 53, 98,
+}
+
+func TestSelectRank(t *testing.T) {
+	for name, tree := range treesAs[orderStatTree[int]](t) {
+		InsertAll[int](tree, orderStatValues...)
+		checkOrderStats(t, name, tree)
+
+		// remove every third value and check again
+		for i := 0; i < len(orderStatValues); i += 3 {
+			tree.Delete(orderStatValues[i])
+			checkOrderStats(t, name, tree)
+		}
+	}
+}
+
+func TestSelectRankBalanced(t *testing.T) {
+	tree := NewBinarySearchTree[int]()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i * 2)
+	}
+	tree.Balance()
+	checkOrderStats(t, "balanced", tree)
+}
+
+func TestSelectRankEmpty(t *testing.T) {
+	for name, tree := range treesAs[orderStatTree[int]](t) {
+		if _, ok := tree.Select(0); ok {
+			t.Error(name, "selected from an empty tree")
+		}
+		if tree.Rank(10) != 0 {
+			t.Error(name, "expected rank 0 in an empty tree")
+		}
+	}
+}
+
+// compare Select and Rank to an in order traversal of the tree.
+func checkOrderStats(t *testing.T, name string, tree orderStatTree[int]) {
+	t.Helper()
+	sorted := make([]int, 0)
+	tree.Traverse(InOrder[int], func(n Node[int]) bool {
+		sorted = append(sorted, n.Value())
+		return true
+	})
+
+	for k, expected := range sorted {
+		if actual, ok := tree.Select(k); !ok || actual != expected {
+			t.Error(name, "Select", k, "expected", expected, "got", actual, ok)
+		}
+
+		if rank := tree.Rank(expected); rank != k {
+			t.Error(name, "Rank", expected, "expected", k, "got", rank)
+		}
+
+		// a value between two elements has the rank of the larger one
+		if rank := tree.Rank(expected + 1); k+1 < len(sorted) && sorted[k+1] > expected+1 && rank != k+1 {
+			t.Error(name, "Rank", expected+1, "expected", k+1, "got", rank)
+		}
+	}
+
+	if _, ok := tree.Select(len(sorted)); ok {
+		t.Error(name, "selected past the end of the tree")
+	}
+	if _, ok := tree.Select(-1); ok {
+		t.Error(name, "selected before the start of the tree")
+	}
+	if rank := tree.Rank(1000); rank != len(sorted) {
+		t.Error(name, "expected rank", len(sorted), "for a value past the end, got", rank)
+	}
+}
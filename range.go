@@ -0,0 +1,130 @@
+package canopy
+
+import (
+	"iter"
+)
+
+// Bounds describes which ends of a range are excluded.
+type Bounds uint8
+
+const (
+	// ExcludeLow excludes the low end of the range.
+	ExcludeLow Bounds = 1 << iota
+	// ExcludeHigh excludes the high end of the range.
+	ExcludeHigh
+
+	// Closed includes both ends, [lo, hi].
+	Closed Bounds = 0
+	// Open excludes both ends, (lo, hi).
+	Open = ExcludeLow | ExcludeHigh
+)
+
+// Visit the values below n that lie between lo and hi in order, skipping subtrees that are out of range.
+func rangeOf[E any](n Node[E], lo, hi E, bounds Bounds, compare func(a, b E) int, visit func(E) bool) bool {
+	value := n.Value()
+	cl := compare(value, lo)
+	ch := compare(value, hi)
+
+	if cl > 0 {
+		if left, ok := n.Left(); ok {
+			if !rangeOf(left, lo, hi, bounds, compare, visit) {
+				return false
+			}
+		}
+	}
+
+	aboveLow := cl > 0 || cl == 0 && bounds&ExcludeLow == 0
+	belowHigh := ch < 0 || ch == 0 && bounds&ExcludeHigh == 0
+	if aboveLow && belowHigh {
+		if !visit(value) {
+			return false
+		}
+	}
+
+	if ch < 0 {
+		if right, ok := n.Right(); ok {
+			if !rangeOf(right, lo, hi, bounds, compare, visit) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Visit the values of t between lo and hi in order.
+func visitRange[E any](t searchTree[E], lo, hi E, bounds Bounds, visit func(E) bool) {
+	if root, ok := t.rootNode(); ok {
+		rangeOf(root, lo, hi, bounds, t.comparator(), visit)
+	}
+}
+
+// Returns an iterator over the values of t between lo and hi.
+func rangeSeq[E any](t searchTree[E], lo, hi E, bounds Bounds) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		visitRange(t, lo, hi, bounds, yield)
+	}
+}
+
+// Range Visits the values in [lo, hi] in ascending order, until visit returns false.
+func (t *bsTree[E, V]) Range(lo, hi E, visit func(E) bool) {
+	visitRange[E](t, lo, hi, Closed, visit)
+}
+
+// RangeBounds Visits the values between lo and hi in ascending order, until visit returns false.
+// bounds selects whether lo and hi are included.
+func (t *bsTree[E, V]) RangeBounds(lo, hi E, bounds Bounds, visit func(E) bool) {
+	visitRange[E](t, lo, hi, bounds, visit)
+}
+
+// Between Returns an iterator over the values between lo and hi in ascending order.
+func (t *bsTree[E, V]) Between(lo, hi E, bounds Bounds) iter.Seq[E] {
+	return rangeSeq[E](t, lo, hi, bounds)
+}
+
+// Range Visits the values in [lo, hi] in ascending order, until visit returns false.  The tree does not splay.
+func (t *splayTree[E, V]) Range(lo, hi E, visit func(E) bool) {
+	visitRange[E](t, lo, hi, Closed, visit)
+}
+
+// RangeBounds Visits the values between lo and hi in ascending order, until visit returns false.
+// bounds selects whether lo and hi are included.
+func (t *splayTree[E, V]) RangeBounds(lo, hi E, bounds Bounds, visit func(E) bool) {
+	visitRange[E](t, lo, hi, bounds, visit)
+}
+
+// Between Returns an iterator over the values between lo and hi in ascending order.
+func (t *splayTree[E, V]) Between(lo, hi E, bounds Bounds) iter.Seq[E] {
+	return rangeSeq[E](t, lo, hi, bounds)
+}
+
+// Range Visits the values in [lo, hi] in ascending order, until visit returns false.
+func (t *rbTree[E, V]) Range(lo, hi E, visit func(E) bool) {
+	visitRange[E](t, lo, hi, Closed, visit)
+}
+
+// RangeBounds Visits the values between lo and hi in ascending order, until visit returns false.
+// bounds selects whether lo and hi are included.
+func (t *rbTree[E, V]) RangeBounds(lo, hi E, bounds Bounds, visit func(E) bool) {
+	visitRange[E](t, lo, hi, bounds, visit)
+}
+
+// Between Returns an iterator over the values between lo and hi in ascending order.
+func (t *rbTree[E, V]) Between(lo, hi E, bounds Bounds) iter.Seq[E] {
+	return rangeSeq[E](t, lo, hi, bounds)
+}
+
+// Range Visits the values in [lo, hi] in ascending order, until visit returns false.
+func (t *avlTree[E, V]) Range(lo, hi E, visit func(E) bool) {
+	visitRange[E](t, lo, hi, Closed, visit)
+}
+
+// RangeBounds Visits the values between lo and hi in ascending order, until visit returns false.
+// bounds selects whether lo and hi are included.
+func (t *avlTree[E, V]) RangeBounds(lo, hi E, bounds Bounds, visit func(E) bool) {
+	visitRange[E](t, lo, hi, bounds, visit)
+}
+
+// Between Returns an iterator over the values between lo and hi in ascending order.
+func (t *avlTree[E, V]) Between(lo, hi E, bounds Bounds) iter.Seq[E] {
+	return rangeSeq[E](t, lo, hi, bounds)
+}
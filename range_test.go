@@ -0,0 +1,82 @@
+package canopy
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+// rangeTree is the part of a tree tested for the range queries.
+type rangeTree[E any] interface {
+	Tree[E]
+	Range(lo, hi E, visit func(E) bool)
+	RangeBounds(lo, hi E, bounds Bounds, visit func(E) bool)
+	Between(lo, hi E, bounds Bounds) iter.Seq[E]
+}
+
+func TestRange(t *testing.T) {
+	type data struct {
+		lo, hi   int
+		bounds   Bounds
+		expected []int
+	}
+
+	testData := []data{
+		{20, 40, Closed, []int{20, 30, 40}},
+		{20, 40, Open, []int{30}},
+		{20, 40, ExcludeLow, []int{30, 40}},
+		{20, 40, ExcludeHigh, []int{20, 30}},
+		{15, 45, Open, []int{20, 30, 40}},
+		{0, 100, Closed, []int{10, 20, 30, 40, 50}},
+		{60, 100, Closed, []int{}},
+		{40, 20, Closed, []int{}},
+	}
+
+	for name, tree := range treesAs[rangeTree[int]](t) {
+		InsertAll[int](tree, 30, 10, 50, 20, 40)
+		for _, d := range testData {
+			actual := make([]int, 0)
+			tree.RangeBounds(d.lo, d.hi, d.bounds, func(v int) bool {
+				actual = append(actual, v)
+				return true
+			})
+			arrayEquals(t, name+" RangeBounds", d.expected, actual)
+
+			actual = slices.AppendSeq(make([]int, 0), tree.Between(d.lo, d.hi, d.bounds))
+			arrayEquals(t, name+" Between", d.expected, actual)
+		}
+	}
+}
+
+func TestRangeStop(t *testing.T) {
+	for name, tree := range treesAs[rangeTree[int]](t) {
+		InsertAll[int](tree, 30, 10, 50, 20, 40)
+
+		actual := make([]int, 0)
+		tree.Range(10, 50, func(v int) bool {
+			actual = append(actual, v)
+			return v < 30
+		})
+		arrayEquals(t, name, []int{10, 20, 30}, actual)
+	}
+}
+
+func TestRangePrunes(t *testing.T) {
+	tree := NewAVLTree[int]()
+	for i := 0; i < 1024; i++ {
+		tree.Insert(i)
+	}
+
+	// count comparisons to make sure subtrees outside the range aren't visited
+	calls := 0
+	tree.compare = func(a, b int) int {
+		calls++
+		return a - b
+	}
+
+	actual := slices.Collect(tree.Between(500, 503, Closed))
+	arrayEquals(t, "", []int{500, 501, 502, 503}, actual)
+	if calls > 100 {
+		t.Error("expected the range to prune the tree, made", calls, "comparisons")
+	}
+}
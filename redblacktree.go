@@ -11,75 +11,144 @@ import (
 // 3) A red node cannot have a red child
 // 4) The path from any given node goes through the same number of black nodes.
 // 5) If a node has a single child, it must be a red child.
-type RBTree[E cmp.Ordered] struct {
-	root *rbNode[E]
+type RBTree[E any] struct {
+	root *rbNode[E, struct{}]
 }
 
-type color uint8
+// Color is the color of a red black tree node.
+type Color uint8
 
 const (
-	black = iota
-	red
+	Black Color = iota
+	Red
 )
 
-func (c color) String() string {
-	if c == black {
+// ColoredNode is implemented by the nodes of a RedBlackTree.
+type ColoredNode[E any] interface {
+	Node[E]
+	Color() Color
+}
+
+func (c Color) String() string {
+	if c == Black {
 		return "black"
 	}
 	return "red"
 }
 
-type rbNode[E cmp.Ordered] struct {
-	value  E
-	parent *rbNode[E]
-	left   *rbNode[E]
-	right  *rbNode[E]
-	color  color
+type rbNode[E, V any] struct {
+	value   E
+	payload V
+	parent  *rbNode[E, V]
+	left    *rbNode[E, V]
+	right   *rbNode[E, V]
+	color   Color
+	size    int // the number of nodes in the subtree rooted here
+}
+
+func (n *rbNode[E, V]) Value() E {
+	return n.value
 }
 
-func (n *rbNode[E]) Value() E {
+func (n *rbNode[E, V]) Key() E {
 	return n.value
 }
 
-func (n *rbNode[E]) p() (Node[E], bool) {
+func (n *rbNode[E, V]) MapValue() V {
+	return n.payload
+}
+
+func (n *rbNode[E, V]) Parent() (Node[E], bool) {
 	return n.parent, n.parent != nil
 }
 
-func (n *rbNode[E]) l() (Node[E], bool) {
+func (n *rbNode[E, V]) Left() (Node[E], bool) {
 	return n.left, n.left != nil
 }
 
-func (n *rbNode[E]) r() (Node[E], bool) {
+func (n *rbNode[E, V]) Right() (Node[E], bool) {
 	return n.right, n.right != nil
 }
 
-type RedBlackTree[E cmp.Ordered] struct {
-	root *rbNode[E]
+func (n *rbNode[E, V]) Color() Color {
+	return n.color
+}
+
+func (n *rbNode[E, V]) IsLeaf() bool {
+	return n.left == nil && n.right == nil
+}
+
+func (n *rbNode[E, V]) count() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// recompute the subtree size of n from its children.
+func (n *rbNode[E, V]) update() {
+	n.size = 1 + n.left.count() + n.right.count()
+}
+
+// rbTree is the red black tree implementation shared by RedBlackTree and TreeMap.
+type rbTree[E, V any] struct {
+	root    *rbNode[E, V]
+	compare func(a, b E) int
+	size    int
+	codec   Codec[E]
+}
+
+// RedBlackTree is a self balancing binary search tree.  The zero value is an empty tree ordered by the
+// natural order of E, when E is a number or a string.
+type RedBlackTree[E any] struct {
+	rbTree[E, struct{}]
 }
 
 // NewRedBlackTree creates a new red black tree.
 func NewRedBlackTree[E cmp.Ordered]() *RedBlackTree[E] {
-	return &RedBlackTree[E]{root: nil}
+	return NewRedBlackTreeFunc(cmp.Compare[E])
 }
 
+// NewRedBlackTreeFunc creates a new red black tree ordered by compare, which returns a negative number
+// when a < b, a positive number when a > b and zero when a == b.
+func NewRedBlackTreeFunc[E any](compare func(a, b E) int) *RedBlackTree[E] {
+	t := new(RedBlackTree[E])
+	t.compare = compare
+	return t
+}
+
+// Insert Places a value into the tree, rebalancing as needed.
+// Returns true if the value was inserted, false if the value exists already.
 func (t *RedBlackTree[E]) Insert(value E) bool {
-	node := &rbNode[E]{value: value, color: red}
+	_, inserted := t.insert(value)
+	return inserted
+}
+
+// Returns the rbNode holding value, and true if the rbNode was created by this call.
+func (t *rbTree[E, V]) insert(value E) (*rbNode[E, V], bool) {
+	if resolveCompare(&t.compare) == nil {
+		panic(ErrNoComparator)
+	}
+
+	node := &rbNode[E, V]{value: value, color: Red, size: 1}
 	if t.root == nil {
 		t.root = node
-		node.color = black
-		return true
+		node.color = Black
+		t.size++
+		return node, true
 	}
 
 	current := t.root
 	for {
-		if value < current.value {
+		x := t.compare(value, current.value)
+		if x < 0 {
 			if current.left == nil {
 				current.left = node
 				node.parent = current
 				break
 			}
 			current = current.left
-		} else if value > current.value {
+		} else if x > 0 {
 			if current.right == nil {
 				current.right = node
 				node.parent = current
@@ -87,65 +156,68 @@ func (t *RedBlackTree[E]) Insert(value E) bool {
 			}
 			current = current.right
 		} else {
-			return false
+			return current, false
 		}
 	}
 
+	for p := node.parent; p != nil; p = p.parent {
+		p.size++
+	}
+
 	t.balance(node)
-	return true
+	t.size++
+	return node, true
 }
 
-func (t *RedBlackTree[E]) balance(n *rbNode[E]) {
-	if n == t.root || n.parent == nil {
-		return
-	}
-
-	for n != t.root && n.parent.color == red {
+func (t *rbTree[E, V]) balance(n *rbNode[E, V]) {
+	for n != t.root && n.parent.color == Red {
 		p := n.parent
 		gp := n.parent.parent
 
-		if gp == nil {
-			// ?? not sure
-			break
-		}
-
 		u := gp.right
 		if p == gp.right {
 			u = gp.left
 		}
 
-		if u != nil && u.color == red { // Case 1: The parent color is red, and the uncle color is red
+		if u != nil && u.color == Red { // Case 1: The parent color is red, and the uncle color is red
 			recolor1(p, u, gp)
-		} else { // Case 2: the parent color is red and the uncle color is black (or nil)
-			if n == p.right && p == gp.left { // Case 2: n, p and gp make a triangle - rotate around parent
-				t.rotateLeft(p)
-			} else if n == p.left && p == gp.right {
-				t.rotateRight(p)
-			} else if n == p.right && p == gp.right { // Case 3: n, p, and gp are in a line: rotate around grandparent
-				t.rotateLeft(gp)
-				recolor3(p, gp)
-			} else if n == p.left && p == gp.left {
-				t.rotateRight(gp)
-				recolor3(p, gp)
-			}
+			n = gp
+			continue
 		}
-		n = p
+
+		// Case 2: the parent color is red and the uncle color is black (or nil)
+		if n == p.right && p == gp.left { // n, p and gp make a triangle - rotate around parent
+			t.rotateLeft(p)
+			n, p = p, n
+		} else if n == p.left && p == gp.right {
+			t.rotateRight(p)
+			n, p = p, n
+		}
+
+		// Case 3: n, p, and gp are in a line: rotate around grandparent
+		if p == gp.right {
+			t.rotateLeft(gp)
+		} else {
+			t.rotateRight(gp)
+		}
+		recolor3(p, gp)
+		break
 	}
-	t.root.color = black
+	t.root.color = Black
 }
 
-func recolor1[E cmp.Ordered](p, u, gp *rbNode[E]) {
-	p.color = black
-	u.color = black
-	gp.color = red
+func recolor1[E, V any](p, u, gp *rbNode[E, V]) {
+	p.color = Black
+	u.color = Black
+	gp.color = Red
 }
 
-func recolor3[E cmp.Ordered](p, gp *rbNode[E]) {
-	p.color = black
-	gp.color = red
+func recolor3[E, V any](p, gp *rbNode[E, V]) {
+	p.color = Black
+	gp.color = Red
 }
 
-func (t *RedBlackTree[E]) rotateLeft(n *rbNode[E]) {
+func (t *rbTree[E, V]) rotateLeft(n *rbNode[E, V]) {
 	p := n.parent
 	c := n.right
 	c.parent = n.parent
@@ -167,9 +239,12 @@ func (t *RedBlackTree[E]) rotateLeft(n *rbNode[E]) {
 	if c.parent == nil {
 		t.root = c
 	}
+
+	n.update()
+	c.update()
 }
 
-func (t *RedBlackTree[E]) rotateRight(n *rbNode[E]) {
+func (t *rbTree[E, V]) rotateRight(n *rbNode[E, V]) {
 	p := n.parent
 
 	c := n.left
@@ -192,35 +267,202 @@ func (t *RedBlackTree[E]) rotateRight(n *rbNode[E]) {
 	if c.parent == nil {
 		t.root = c
 	}
+
+	n.update()
+	c.update()
 }
 
-func rbfind[E cmp.Ordered](n *rbNode[E], value E) *rbNode[E] {
-	for n != nil && n.value != value {
-		if value < n.value {
+func rbfind[E, V any](n *rbNode[E, V], value E, compare func(a, b E) int) *rbNode[E, V] {
+	for n != nil {
+		x := compare(value, n.value)
+		if x < 0 {
 			n = n.left
-		} else if value > n.value {
+		} else if x > 0 {
 			n = n.right
+		} else {
+			break
 		}
 	}
 	return n
 }
 
+// Delete Removes a value from the tree, rebalancing as needed.
+// Returns true if the value was removed.
 func (t *RedBlackTree[E]) Delete(value E) bool {
-	node := rbfind(t.root, value)
-	if node == nil {
-		return false
+	return t.remove(value) != nil
+}
+
+// Unlinks the rbNode holding value from the tree, returns the removed rbNode or nil if value wasn't found.
+func (t *rbTree[E, V]) remove(value E) *rbNode[E, V] {
+	z := rbfind(t.root, value, t.compare)
+	if z == nil {
+		return nil
+	}
+
+	// y is the node that is physically removed from the tree, x is the node that moves into y's place.
+	// x may be nil, so its parent is tracked separately.
+	y := z
+	removedColor := y.color
+	var x, xParent *rbNode[E, V]
+
+	if z.left == nil {
+		x = z.right
+		xParent = z.parent
+		t.transplant(z, z.right)
+	} else if z.right == nil {
+		x = z.left
+		xParent = z.parent
+		t.transplant(z, z.left)
+	} else { // two children: replace z with its inorder successor
+		y = leftmost[E](z.right).(*rbNode[E, V])
+		removedColor = y.color
+		x = y.right
+		if y.parent == z {
+			xParent = y
+		} else {
+			xParent = y.parent
+			t.transplant(y, y.right)
+			y.right = z.right
+			y.right.parent = y
+		}
+		t.transplant(z, y)
+		y.left = z.left
+		y.left.parent = y
+		y.color = z.color
+	}
+
+	for p := xParent; p != nil; p = p.parent {
+		p.update()
+	}
+
+	if removedColor == Black {
+		t.deleteFixup(x, xParent)
+	}
+
+	t.size--
+	z.parent = nil
+	z.left = nil
+	z.right = nil
+	z.size = 1
+	return z
+}
+
+// deleteFixup restores the red black properties after a black node was removed.  x carries an "extra" black,
+// and p is the parent of x, since x may be nil.
+func (t *rbTree[E, V]) deleteFixup(x, p *rbNode[E, V]) {
+	for x != t.root && isBlack(x) {
+		if x == p.left {
+			w := p.right
+			if w.color == Red { // Case 1: the sibling is red
+				w.color = Black
+				p.color = Red
+				t.rotateLeft(p)
+				w = p.right
+			}
+
+			if isBlack(w.left) && isBlack(w.right) { // Case 2: the sibling is black with two black children
+				w.color = Red
+				x = p
+				p = x.parent
+			} else {
+				if isBlack(w.right) { // Case 3: the sibling is black, its near child is red
+					w.left.color = Black
+					w.color = Red
+					t.rotateRight(w)
+					w = p.right
+				}
+				// Case 4: the sibling is black, its far child is red
+				w.color = p.color
+				p.color = Black
+				w.right.color = Black
+				t.rotateLeft(p)
+				x = t.root
+			}
+		} else {
+			w := p.left
+			if w.color == Red {
+				w.color = Black
+				p.color = Red
+				t.rotateRight(p)
+				w = p.left
+			}
+
+			if isBlack(w.left) && isBlack(w.right) {
+				w.color = Red
+				x = p
+				p = x.parent
+			} else {
+				if isBlack(w.left) {
+					w.right.color = Black
+					w.color = Red
+					t.rotateLeft(w)
+					w = p.left
+				}
+				w.color = p.color
+				p.color = Black
+				w.left.color = Black
+				t.rotateRight(p)
+				x = t.root
+			}
+		}
 	}
-	// todo - rebalance
-	return true
+
+	if x != nil {
+		x.color = Black
+	}
+}
+
+// transplant replaces the subtree rooted at u with the subtree rooted at v.
+func (t *rbTree[E, V]) transplant(u, v *rbNode[E, V]) {
+	if u.parent == nil {
+		t.root = v
+	} else if u == u.parent.left {
+		u.parent.left = v
+	} else {
+		u.parent.right = v
+	}
+
+	if v != nil {
+		v.parent = u.parent
+	}
+}
+
+// nil nodes are considered black
+func isBlack[E, V any](n *rbNode[E, V]) bool {
+	return n == nil || n.color == Black
 }
 
 func (t *RedBlackTree[E]) Find(value E) bool {
-	node := rbfind(t.root, value)
+	node := rbfind(t.root, value, t.compare)
 	return node != nil
 }
 
-func (t *RedBlackTree[E]) Traverse(method func(node Node[E], v func(node Node[E]) bool) bool, v func(node Node[E]) bool) {
+func (t *rbTree[E, V]) Traverse(method func(node Node[E], v func(node Node[E]) bool) bool, v func(node Node[E]) bool) {
 	if t.root != nil {
 		method(t.root, v)
 	}
 }
+
+func (t *rbTree[E, V]) rootNode() (Node[E], bool) {
+	return t.root, t.root != nil
+}
+
+func (t *rbTree[E, V]) removeValue(value E) bool {
+	return t.remove(value) != nil
+}
+
+// Len Returns the number of values in the tree.
+func (t *rbTree[E, V]) Len() int {
+	return t.size
+}
+
+// IsEmpty Returns true if the tree holds no values.
+func (t *rbTree[E, V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Clear Removes every value from the tree.
+func (t *rbTree[E, V]) Clear() {
+	t.root = nil
+	t.size = 0
+}
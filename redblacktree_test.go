@@ -32,13 +32,13 @@ func TestRedBlack_Case3Right(t *testing.T) {
 	tree.Insert(52)
 
 	n := tree.root
-	if fail, mesg := checkNode[int](n, 42, black); fail {
+	if fail, mesg := checkNode[int](n, 42, Black); fail {
 		t.Fatal(mesg)
 	}
-	if fail, mesg := checkNode[int](n.left, 32, red); fail {
+	if fail, mesg := checkNode[int](n.left, 32, Red); fail {
 		t.Fatal(mesg)
 	}
-	if fail, mesg := checkNode[int](n.right, 52, red); fail {
+	if fail, mesg := checkNode[int](n.right, 52, Red); fail {
 		t.Fatal(mesg)
 	}
 }
@@ -47,7 +47,7 @@ func TestRedBlack_Case2Left(t *testing.T) {
 
 }
 
-func checkNode[E cmp.Ordered](n *rbNode[E], value E, color color) (bool, string) {
+func checkNode[E cmp.Ordered](n *rbNode[E, struct{}], value E, color Color) (bool, string) {
 	if n == nil {
 		return true, "node is nil"
 	}
@@ -72,7 +72,7 @@ func TestRedBlack_fourNodes(t *testing.T) {
 // provide visual confirmation about node color
 func printRBTree[E cmp.Ordered](tree *RedBlackTree[E]) {
 	printer := func(n Node[E]) bool {
-		node := n.(*rbNode[E])
+		node := n.(*rbNode[E, struct{}])
 		template := "%v(%s) L %v R %v\n"
 		leftValue := "nil"
 		rightValue := "nil"
@@ -89,3 +89,123 @@ func printRBTree[E cmp.Ordered](tree *RedBlackTree[E]) {
 
 	tree.Traverse(BreadthFirst[E], printer)
 }
+
+func TestRedBlack_deleteLeaf(t *testing.T) {
+	tree := NewRedBlackTree[int]()
+	InsertAll(tree, 32, 42, 52)
+
+	if !tree.Delete(52) {
+		t.Fatal("delete 52 failed")
+	}
+
+	if tree.Find(52) {
+		t.Error("52 was found after delete")
+	}
+	checkRedBlack(t, tree)
+}
+
+func TestRedBlack_deleteRoot(t *testing.T) {
+	tree := NewRedBlackTree[int]()
+	tree.Insert(42)
+
+	if !tree.Delete(42) {
+		t.Fatal("delete 42 failed")
+	}
+
+	if tree.root != nil {
+		t.Error("expected an empty tree")
+	}
+
+	if tree.Delete(42) {
+		t.Error("deleted 42 from an empty tree")
+	}
+}
+
+func TestRedBlack_deleteNotFound(t *testing.T) {
+	tree := NewRedBlackTree[int]()
+	InsertAll(tree, 32, 42, 52)
+
+	if tree.Delete(100) {
+		t.Error("deleted a value not in the tree")
+	}
+	checkRedBlack(t, tree)
+}
+
+func TestRedBlack_deleteAll(t *testing.T) {
+	values := []int{
+		3, 36, 93, 61, 23, 83, 6, 25, 13, 66,
+		39, 63, 30, 20, 19, 21, 78, 72, 46, 40,
+		92, 84, 47, 24, 58, 89, 96, 26, 53, 98,
+		9, 10, 45, 11, 79, 55, 42, 90, 37, 17,
+		86, 12, 76, 28, 65, 99, 70, 44, 100,
+		29, 43, 87, 56, 51, 95, 7, 5, 50,
+	}
+	tree := NewRedBlackTree[int]()
+	InsertAll(tree, values...)
+	checkRedBlack(t, tree)
+
+	// delete in a different order than insertion
+	for i := len(values) - 1; i >= 0; i -= 2 {
+		if !tree.Delete(values[i]) {
+			t.Fatal("failed to delete", values[i])
+		}
+		checkRedBlack(t, tree)
+	}
+
+	for i := len(values) - 2; i >= 0; i -= 2 {
+		if !tree.Delete(values[i]) {
+			t.Fatal("failed to delete", values[i])
+		}
+		checkRedBlack(t, tree)
+	}
+
+	if tree.root != nil {
+		printRBTree[int](tree)
+		t.Error("expected an empty tree")
+	}
+}
+
+// checkRedBlack verifies the red black properties of the tree.
+func checkRedBlack[E cmp.Ordered](t *testing.T, tree *RedBlackTree[E]) {
+	t.Helper()
+	if tree.root == nil {
+		return
+	}
+
+	if tree.root.color != Black {
+		t.Error("root is not black")
+	}
+
+	var blackHeight func(n *rbNode[E, struct{}]) int
+	blackHeight = func(n *rbNode[E, struct{}]) int {
+		if n == nil {
+			return 1
+		}
+
+		if n.left != nil && (n.left.parent != n || n.left.value >= n.value) {
+			t.Errorf("bad left child of %v", n.value)
+		}
+		if n.right != nil && (n.right.parent != n || n.right.value <= n.value) {
+			t.Errorf("bad right child of %v", n.value)
+		}
+		if n.color == Red && (!isBlack(n.left) || !isBlack(n.right)) {
+			t.Errorf("red node %v has a red child", n.value)
+		}
+
+		if n.size != 1+n.left.count()+n.right.count() {
+			t.Errorf("node %v has the wrong subtree size %d", n.value, n.size)
+		}
+
+		lh := blackHeight(n.left)
+		rh := blackHeight(n.right)
+		if lh != rh {
+			t.Errorf("black height mismatch at %v: %d != %d", n.value, lh, rh)
+		}
+
+		if n.color == Black {
+			lh++
+		}
+		return lh
+	}
+	blackHeight(tree.root)
+}
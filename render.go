@@ -0,0 +1,249 @@
+package canopy
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// RenderOption configures WriteTree.
+type RenderOption func(c *renderConfig)
+
+type renderConfig struct {
+	color, height, size bool
+	maxDepth            int
+	sideways            bool
+	glyphs              glyphs
+}
+
+// The characters used to draw edges.
+type glyphs struct {
+	topLeft, topRight, horizontal, vertical, bottomLeft, ellipsis string
+}
+
+var (
+	unicodeGlyphs = glyphs{"┌", "┐", "─", "│", "└", "…"}
+	asciiGlyphs   = glyphs{"+", "+", "-", "|", "+", "..."}
+)
+
+// ShowColor Annotates red black nodes with their color, R or B.
+func ShowColor() RenderOption {
+	return func(c *renderConfig) {
+		c.color = true
+	}
+}
+
+// ShowHeight Annotates every node with the height of its subtree, a leaf has a height of one.
+func ShowHeight() RenderOption {
+	return func(c *renderConfig) {
+		c.height = true
+	}
+}
+
+// ShowSize Annotates every node with the number of nodes in its subtree.
+func ShowSize() RenderOption {
+	return func(c *renderConfig) {
+		c.size = true
+	}
+}
+
+// MaxDepth Stops drawing below depth, where the root has a depth of zero.  Subtrees that are cut off are
+// drawn as an ellipsis.
+func MaxDepth(depth int) RenderOption {
+	return func(c *renderConfig) {
+		c.maxDepth = depth
+	}
+}
+
+// Sideways Draws the tree on its side with the root on the left and the right subtree above it.  A sideways
+// tree is only as wide as its depth, which suits large trees.
+func Sideways() RenderOption {
+	return func(c *renderConfig) {
+		c.sideways = true
+	}
+}
+
+// ASCII Draws edges with ASCII characters instead of box drawing characters.
+func ASCII() RenderOption {
+	return func(c *renderConfig) {
+		c.glyphs = asciiGlyphs
+	}
+}
+
+// WriteTree Draws tree to w.  By default the tree is drawn top down, with each parent centered over the
+// edges to its children and every column as wide as the labels beneath it:
+//
+//	   ┌50─┐
+//	 ┌30  70
+//	20
+//
+// Label widths are counted in runes, so labels holding characters that don't take exactly one column,
+// such as wide East Asian characters or combining marks, misalign the drawing.
+func WriteTree[E any](w io.Writer, tree Tree[E], options ...RenderOption) error {
+	c := renderConfig{maxDepth: -1, glyphs: unicodeGlyphs}
+	for _, o := range options {
+		o(&c)
+	}
+
+	r := renderer[E]{renderConfig: c, heights: make(map[Node[E]]int)}
+	b := bufio.NewWriter(w)
+	if root, ok := rootOf(tree); ok {
+		if c.sideways {
+			r.sideways(b, root, 0, "", "", "")
+		} else {
+			for _, line := range r.topDown(root, 0).lines {
+				b.WriteString(strings.TrimRight(line, " ") + "\n")
+			}
+		}
+	}
+	return b.Flush()
+}
+
+type renderer[E any] struct {
+	renderConfig
+	heights map[Node[E]]int
+}
+
+// Returns the text drawn for n.
+func (r *renderer[E]) label(n Node[E]) string {
+	notes := make([]string, 0, 3)
+	if cn, ok := n.(ColoredNode[E]); ok && r.color {
+		notes = append(notes, strings.ToUpper(cn.Color().String()[:1]))
+	}
+	if r.height {
+		notes = append(notes, fmt.Sprintf("h=%d", r.heightOf(n)))
+	}
+	if r.size {
+		notes = append(notes, fmt.Sprintf("n=%d", subtreeSize(n)))
+	}
+
+	label := fmt.Sprint(n.Value())
+	if len(notes) > 0 {
+		label += " (" + strings.Join(notes, " ") + ")"
+	}
+	return label
+}
+
+// Returns the height of the subtree below n, remembering the heights of every node below it.
+func (r *renderer[E]) heightOf(n Node[E]) int {
+	if h, ok := r.heights[n]; ok {
+		return h
+	}
+
+	h := 0
+	for _, child := range []func() (Node[E], bool){n.Left, n.Right} {
+		if c, ok := child(); ok {
+			h = max(h, r.heightOf(c))
+		}
+	}
+	r.heights[n] = h + 1
+	return h + 1
+}
+
+// Returns true if the children of a node at depth are cut off by the depth limit.
+func (r *renderer[E]) cut(depth int) bool {
+	return r.maxDepth >= 0 && depth >= r.maxDepth
+}
+
+// block is a drawn subtree, every line is padded to the same width.
+type block struct {
+	lines []string
+	width int
+	mid   int // the column an edge from the parent joins at
+}
+
+func leafBlock(label string) *block {
+	width := utf8.RuneCountInString(label)
+	return &block{lines: []string{label}, width: width, mid: width / 2}
+}
+
+// Draws the subtree below n with the root label on the top line, between the blocks of its subtrees.
+func (r *renderer[E]) topDown(n Node[E], depth int) *block {
+	label := r.label(n)
+	labelWidth := utf8.RuneCountInString(label)
+	if labelWidth == 0 {
+		// an edge must have a column to join at, even for an empty value
+		label, labelWidth = " ", 1
+	}
+
+	var children [2]*block
+	for i, child := range []func() (Node[E], bool){n.Left, n.Right} {
+		if c, ok := child(); ok {
+			if r.cut(depth) {
+				children[i] = leafBlock(r.glyphs.ellipsis)
+			} else {
+				children[i] = r.topDown(c, depth+1)
+			}
+		}
+	}
+
+	left, right := children[0], children[1]
+	if left == nil && right == nil {
+		return leafBlock(label)
+	}
+
+	var top strings.Builder
+	leftWidth, rightWidth, rows := 0, 0, 0
+	if left != nil {
+		leftWidth = left.width
+		rows = len(left.lines)
+		top.WriteString(strings.Repeat(" ", left.mid))
+		top.WriteString(r.glyphs.topLeft)
+		top.WriteString(strings.Repeat(r.glyphs.horizontal, left.width-left.mid-1))
+	}
+	top.WriteString(label)
+	if right != nil {
+		rightWidth = right.width
+		rows = max(rows, len(right.lines))
+		top.WriteString(strings.Repeat(r.glyphs.horizontal, right.mid))
+		top.WriteString(r.glyphs.topRight)
+		top.WriteString(strings.Repeat(" ", right.width-right.mid-1))
+	}
+
+	b := &block{
+		lines: []string{top.String()},
+		width: leftWidth + labelWidth + rightWidth,
+		mid:   leftWidth + labelWidth/2,
+	}
+	for i := 0; i < rows; i++ {
+		b.lines = append(b.lines, left.line(i, leftWidth)+strings.Repeat(" ", labelWidth)+right.line(i, rightWidth))
+	}
+	return b
+}
+
+// Returns line i of the block, or blank padding when the block is shorter or missing.
+func (b *block) line(i, width int) string {
+	if b == nil || i >= len(b.lines) {
+		return strings.Repeat(" ", width)
+	}
+	return b.lines[i]
+}
+
+// Draws the subtree below n on its side.  above, self and below are the prefixes of the lines above n,
+// of the line holding n and of the lines below n.
+func (r *renderer[E]) sideways(w *bufio.Writer, n Node[E], depth int, above, self, below string) {
+	g := r.glyphs
+	edge := strings.Repeat(g.horizontal, 2) + " "
+	blank := strings.Repeat(" ", 4)
+	bar := g.vertical + strings.Repeat(" ", 3)
+
+	if right, ok := n.Right(); ok {
+		if r.cut(depth) {
+			w.WriteString(above + g.topLeft + edge + g.ellipsis + "\n")
+		} else {
+			r.sideways(w, right, depth+1, above+blank, above+g.topLeft+edge, above+bar)
+		}
+	}
+
+	w.WriteString(self + r.label(n) + "\n")
+
+	if left, ok := n.Left(); ok {
+		if r.cut(depth) {
+			w.WriteString(below + g.bottomLeft + edge + g.ellipsis + "\n")
+		} else {
+			r.sideways(w, left, depth+1, below+bar, below+g.bottomLeft+edge, below+blank)
+		}
+	}
+}
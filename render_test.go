@@ -0,0 +1,110 @@
+package canopy
+
+import (
+	"bytes"
+	"testing"
+)
+
+func renderString[E any](t *testing.T, tree Tree[E], options ...RenderOption) string {
+	t.Helper()
+	var b bytes.Buffer
+	if err := WriteTree[E](&b, tree, options...); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+func renderTree() *BSTree[int] {
+	tree := NewBinarySearchTree[int]()
+	InsertAll[int](tree, 50, 30, 70, 20, 40, 60, 80, 10, 100, 65)
+	return tree
+}
+
+func TestWriteTree(t *testing.T) {
+	expected := `     ┌──50─────┐
+   ┌30─┐   ┌──70─┐
+ ┌20  40  60─┐  80─┐
+10          65    100
+`
+	if out := renderString[int](t, renderTree()); out != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, out)
+	}
+}
+
+func TestWriteTreeSideways(t *testing.T) {
+	expected := `        ┌── 100
+    ┌── 80
+┌── 70
+│   │   ┌── 65
+│   └── 60
+50
+│   ┌── 40
+└── 30
+    └── 20
+        └── 10
+`
+	if out := renderString[int](t, renderTree(), Sideways()); out != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, out)
+	}
+}
+
+func TestWriteTreeDepth(t *testing.T) {
+	expected := `         +--------50 (h=4 n=10)---------+
+ +-30 (h=3 n=4)-+               +-70 (h=3 n=5)-+
+...            ...             ...            ...
+`
+	out := renderString[int](t, renderTree(), ASCII(), MaxDepth(1), ShowHeight(), ShowSize())
+	if out != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, out)
+	}
+
+	expected = `+-- ...
+50
++-- ...
+`
+	if out := renderString[int](t, renderTree(), ASCII(), MaxDepth(0), Sideways()); out != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, out)
+	}
+}
+
+func TestWriteTreeColor(t *testing.T) {
+	tree := NewRedBlackTree[int]()
+	InsertAll[int](tree, 50, 30, 70, 20)
+
+	expected := `         ┌──50 (B)───┐
+   ┌──30 (B)      70 (B)
+20 (R)
+`
+	if out := renderString[int](t, tree, ShowColor()); out != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, out)
+	}
+
+	// color is only shown for red black trees
+	bst := NewBinarySearchTree[int]()
+	InsertAll[int](bst, 50, 70)
+	if out := renderString[int](t, bst, ShowColor()); out != "50─┐\n  70\n" {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestWriteTreeEmpty(t *testing.T) {
+	if out := renderString[int](t, NewAVLTree[int]()); out != "" {
+		t.Errorf("expected no output, got:\n%s", out)
+	}
+}
+
+func TestWriteTreeEmptyLabel(t *testing.T) {
+	tree := NewBinarySearchTree[string]()
+	InsertAll[string](tree, "b", "", "c")
+
+	expected := "┌b┐\n  c\n"
+	if out := renderString[string](t, tree); out != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, out)
+	}
+
+	// the empty value is drawn as a blank column
+	tree.Delete("b")
+	if out := renderString[string](t, tree); out != "┌c\n\n" {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
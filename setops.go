@@ -0,0 +1,232 @@
+package canopy
+
+import (
+	"iter"
+)
+
+// Merge two ascending sequences, keeping the values for which keep returns true.  keep is told whether
+// the value is in a, b or both.
+func merge[E any](a, b iter.Seq[E], compare func(a, b E) int, keep func(inA, inB bool) bool) []E {
+	nextA, stopA := iter.Pull(a)
+	defer stopA()
+	nextB, stopB := iter.Pull(b)
+	defer stopB()
+
+	result := make([]E, 0)
+	va, okA := nextA()
+	vb, okB := nextB()
+	for okA || okB {
+		var x int
+		if !okA {
+			x = 1
+		} else if !okB {
+			x = -1
+		} else {
+			x = compare(va, vb)
+		}
+
+		if x < 0 {
+			if keep(true, false) {
+				result = append(result, va)
+			}
+			va, okA = nextA()
+		} else if x > 0 {
+			if keep(false, true) {
+				result = append(result, vb)
+			}
+			vb, okB = nextB()
+		} else {
+			if keep(true, true) {
+				result = append(result, va)
+			}
+			va, okA = nextA()
+			vb, okB = nextB()
+		}
+	}
+	return result
+}
+
+func union(inA, inB bool) bool {
+	return inA || inB
+}
+
+func intersection(inA, inB bool) bool {
+	return inA && inB
+}
+
+func difference(inA, inB bool) bool {
+	return inA && !inB
+}
+
+func symmetricDifference(inA, inB bool) bool {
+	return inA != inB
+}
+
+// Returns true if every value of a is in b.
+func isSubset[E any](a, b iter.Seq[E], compare func(a, b E) int) bool {
+	nextB, stopB := iter.Pull(b)
+	defer stopB()
+
+	vb, okB := nextB()
+	for va := range a {
+		for okB && compare(vb, va) < 0 {
+			vb, okB = nextB()
+		}
+		if !okB || compare(vb, va) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Union Returns a new tree holding the values that are in either t or other.
+func (t *BSTree[E]) Union(other *BSTree[E]) *BSTree[E] {
+	result := NewBinarySearchTreeFunc(t.comparator())
+	result.build(merge(t.All(), other.All(), t.comparator(), union))
+	return result
+}
+
+// Intersection Returns a new tree holding the values that are in both t and other.
+func (t *BSTree[E]) Intersection(other *BSTree[E]) *BSTree[E] {
+	result := NewBinarySearchTreeFunc(t.comparator())
+	result.build(merge(t.All(), other.All(), t.comparator(), intersection))
+	return result
+}
+
+// Difference Returns a new tree holding the values of t that are not in other.
+func (t *BSTree[E]) Difference(other *BSTree[E]) *BSTree[E] {
+	result := NewBinarySearchTreeFunc(t.comparator())
+	result.build(merge(t.All(), other.All(), t.comparator(), difference))
+	return result
+}
+
+// SymmetricDifference Returns a new tree holding the values that are in t or other, but not both.
+func (t *BSTree[E]) SymmetricDifference(other *BSTree[E]) *BSTree[E] {
+	result := NewBinarySearchTreeFunc(t.comparator())
+	result.build(merge(t.All(), other.All(), t.comparator(), symmetricDifference))
+	return result
+}
+
+// IsSubset Returns true if every value of t is in other.
+func (t *BSTree[E]) IsSubset(other *BSTree[E]) bool {
+	return t.Len() <= other.Len() && isSubset(t.All(), other.All(), t.comparator())
+}
+
+// Equal Returns true if t and other hold the same values.
+func (t *BSTree[E]) Equal(other *BSTree[E]) bool {
+	return t.Len() == other.Len() && isSubset(t.All(), other.All(), t.comparator())
+}
+
+// Union Returns a new tree holding the values that are in either t or other.
+func (t *SplayTree[E]) Union(other *SplayTree[E]) *SplayTree[E] {
+	result := NewSplayTreeFunc(t.comparator())
+	result.build(merge(t.All(), other.All(), t.comparator(), union))
+	return result
+}
+
+// Intersection Returns a new tree holding the values that are in both t and other.
+func (t *SplayTree[E]) Intersection(other *SplayTree[E]) *SplayTree[E] {
+	result := NewSplayTreeFunc(t.comparator())
+	result.build(merge(t.All(), other.All(), t.comparator(), intersection))
+	return result
+}
+
+// Difference Returns a new tree holding the values of t that are not in other.
+func (t *SplayTree[E]) Difference(other *SplayTree[E]) *SplayTree[E] {
+	result := NewSplayTreeFunc(t.comparator())
+	result.build(merge(t.All(), other.All(), t.comparator(), difference))
+	return result
+}
+
+// SymmetricDifference Returns a new tree holding the values that are in t or other, but not both.
+func (t *SplayTree[E]) SymmetricDifference(other *SplayTree[E]) *SplayTree[E] {
+	result := NewSplayTreeFunc(t.comparator())
+	result.build(merge(t.All(), other.All(), t.comparator(), symmetricDifference))
+	return result
+}
+
+// IsSubset Returns true if every value of t is in other.
+func (t *SplayTree[E]) IsSubset(other *SplayTree[E]) bool {
+	return t.Len() <= other.Len() && isSubset(t.All(), other.All(), t.comparator())
+}
+
+// Equal Returns true if t and other hold the same values.
+func (t *SplayTree[E]) Equal(other *SplayTree[E]) bool {
+	return t.Len() == other.Len() && isSubset(t.All(), other.All(), t.comparator())
+}
+
+// Union Returns a new tree holding the values that are in either t or other.
+func (t *RedBlackTree[E]) Union(other *RedBlackTree[E]) *RedBlackTree[E] {
+	result := NewRedBlackTreeFunc(t.comparator())
+	result.build(merge(t.All(), other.All(), t.comparator(), union))
+	return result
+}
+
+// Intersection Returns a new tree holding the values that are in both t and other.
+func (t *RedBlackTree[E]) Intersection(other *RedBlackTree[E]) *RedBlackTree[E] {
+	result := NewRedBlackTreeFunc(t.comparator())
+	result.build(merge(t.All(), other.All(), t.comparator(), intersection))
+	return result
+}
+
+// Difference Returns a new tree holding the values of t that are not in other.
+func (t *RedBlackTree[E]) Difference(other *RedBlackTree[E]) *RedBlackTree[E] {
+	result := NewRedBlackTreeFunc(t.comparator())
+	result.build(merge(t.All(), other.All(), t.comparator(), difference))
+	return result
+}
+
+// SymmetricDifference Returns a new tree holding the values that are in t or other, but not both.
+func (t *RedBlackTree[E]) SymmetricDifference(other *RedBlackTree[E]) *RedBlackTree[E] {
+	result := NewRedBlackTreeFunc(t.comparator())
+	result.build(merge(t.All(), other.All(), t.comparator(), symmetricDifference))
+	return result
+}
+
+// IsSubset Returns true if every value of t is in other.
+func (t *RedBlackTree[E]) IsSubset(other *RedBlackTree[E]) bool {
+	return t.Len() <= other.Len() && isSubset(t.All(), other.All(), t.comparator())
+}
+
+// Equal Returns true if t and other hold the same values.
+func (t *RedBlackTree[E]) Equal(other *RedBlackTree[E]) bool {
+	return t.Len() == other.Len() && isSubset(t.All(), other.All(), t.comparator())
+}
+
+// Union Returns a new tree holding the values that are in either t or other.
+func (t *AVLTree[E]) Union(other *AVLTree[E]) *AVLTree[E] {
+	result := NewAVLTreeFunc(t.comparator())
+	result.build(merge(t.All(), other.All(), t.comparator(), union))
+	return result
+}
+
+// Intersection Returns a new tree holding the values that are in both t and other.
+func (t *AVLTree[E]) Intersection(other *AVLTree[E]) *AVLTree[E] {
+	result := NewAVLTreeFunc(t.comparator())
+	result.build(merge(t.All(), other.All(), t.comparator(), intersection))
+	return result
+}
+
+// Difference Returns a new tree holding the values of t that are not in other.
+func (t *AVLTree[E]) Difference(other *AVLTree[E]) *AVLTree[E] {
+	result := NewAVLTreeFunc(t.comparator())
+	result.build(merge(t.All(), other.All(), t.comparator(), difference))
+	return result
+}
+
+// SymmetricDifference Returns a new tree holding the values that are in t or other, but not both.
+func (t *AVLTree[E]) SymmetricDifference(other *AVLTree[E]) *AVLTree[E] {
+	result := NewAVLTreeFunc(t.comparator())
+	result.build(merge(t.All(), other.All(), t.comparator(), symmetricDifference))
+	return result
+}
+
+// IsSubset Returns true if every value of t is in other.
+func (t *AVLTree[E]) IsSubset(other *AVLTree[E]) bool {
+	return t.Len() <= other.Len() && isSubset(t.All(), other.All(), t.comparator())
+}
+
+// Equal Returns true if t and other hold the same values.
+func (t *AVLTree[E]) Equal(other *AVLTree[E]) bool {
+	return t.Len() == other.Len() && isSubset(t.All(), other.All(), t.comparator())
+}
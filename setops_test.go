@@ -0,0 +1,120 @@
+package canopy
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+// setTree is a tree whose set operations take and return its own type T.
+type setTree[E any, T any] interface {
+	Tree[E]
+	All() iter.Seq[E]
+	Union(other T) T
+	Intersection(other T) T
+	Difference(other T) T
+	SymmetricDifference(other T) T
+	IsSubset(other T) bool
+	Equal(other T) bool
+}
+
+func TestSetOperations(t *testing.T) {
+	checkSetOperations(t, "bst", NewBinarySearchTree[int])
+	checkSetOperations(t, "splay", NewSplayTree[int])
+	checkSetOperations(t, "redblack", NewRedBlackTree[int])
+	checkSetOperations(t, "avl", NewAVLTree[int])
+}
+
+func checkSetOperations[T setTree[int, T]](t *testing.T, name string, newTree func() T) {
+	a := newTree()
+	InsertAll[int](a, 1, 3, 5, 7, 9, 11)
+	b := newTree()
+	InsertAll[int](b, 3, 4, 5, 6, 11, 12)
+
+	arrayEquals(t, name+" Union", []int{1, 3, 4, 5, 6, 7, 9, 11, 12}, slices.Collect(a.Union(b).All()))
+	arrayEquals(t, name+" Intersection", []int{3, 5, 11}, slices.Collect(a.Intersection(b).All()))
+	arrayEquals(t, name+" Difference", []int{1, 7, 9}, slices.Collect(a.Difference(b).All()))
+	arrayEquals(t, name+" Difference", []int{4, 6, 12}, slices.Collect(b.Difference(a).All()))
+	arrayEquals(t, name+" SymmetricDifference", []int{1, 4, 6, 7, 9, 12}, slices.Collect(a.SymmetricDifference(b).All()))
+
+	u := a.Union(b)
+	if u.Len() != 9 || !u.Find(12) || u.Find(2) {
+		t.Error(name, "union is not a searchable tree")
+	}
+
+	empty := newTree()
+	arrayEquals(t, name+" Union empty", []int{1, 3, 5, 7, 9, 11}, slices.Collect(a.Union(empty).All()))
+	if !empty.Intersection(a).IsEmpty() {
+		t.Error(name, "intersection with an empty tree is not empty")
+	}
+
+	if a.IsSubset(b) || !a.Intersection(b).IsSubset(b) || !empty.IsSubset(a) || !a.IsSubset(a) {
+		t.Error(name, "IsSubset failed")
+	}
+
+	if a.Equal(b) || !a.Equal(a) || !a.Union(b).Equal(b.Union(a)) || a.Equal(empty) {
+		t.Error(name, "Equal failed")
+	}
+
+	c := newTree()
+	InsertAll[int](c, 1, 3, 5, 7, 9, 10)
+	if a.Equal(c) || c.IsSubset(a) {
+		t.Error(name, "trees of the same size with different values compared equal")
+	}
+}
+
+func TestSetOperationsZeroValue(t *testing.T) {
+	checkSetOperationsZeroValue(t, "bst", &BSTree[int]{}, NewBinarySearchTree[int]())
+	checkSetOperationsZeroValue(t, "splay", &SplayTree[int]{}, NewSplayTree[int]())
+	checkSetOperationsZeroValue(t, "redblack", &RedBlackTree[int]{}, NewRedBlackTree[int]())
+	checkSetOperationsZeroValue(t, "avl", &AVLTree[int]{}, NewAVLTree[int]())
+}
+
+// zero is a zero value tree that has never had a value inserted, so it has no comparison function yet.
+func checkSetOperationsZeroValue[T setTree[int, T]](t *testing.T, name string, zero T, other T) {
+	InsertAll[int](other, 2, 1)
+
+	u := zero.Union(other)
+	if !u.Find(1) || !u.Find(2) || u.Find(3) {
+		t.Error(name, "union with a zero value tree is not a searchable tree")
+	}
+	u.Insert(3)
+	arrayEquals(t, name+" Union zero", []int{1, 2, 3}, slices.Collect(u.All()))
+
+	if !zero.Intersection(other).IsEmpty() || !zero.IsSubset(other) || zero.Equal(other) {
+		t.Error(name, "set operations failed on a zero value tree")
+	}
+	arrayEquals(t, name+" SymmetricDifference zero", []int{1, 2}, slices.Collect(zero.SymmetricDifference(other).All()))
+}
+
+func TestRedBlack_build(t *testing.T) {
+	for n := 0; n < 130; n++ {
+		values := make([]int, n)
+		for i := range values {
+			values[i] = i
+		}
+
+		tree := NewRedBlackTree[int]()
+		tree.build(values)
+		checkRedBlack(t, tree)
+		arrayEquals(t, "", values, slices.Collect(tree.All()))
+
+		// the built tree must keep working with inserts and deletes
+		tree.Insert(-1)
+		tree.Delete(n / 2)
+		checkRedBlack(t, tree)
+	}
+}
+
+func TestAVL_build(t *testing.T) {
+	for n := 0; n < 130; n++ {
+		values := make([]int, n)
+		for i := range values {
+			values[i] = i
+		}
+
+		tree := NewAVLTree[int]()
+		tree.build(values)
+		checkAVL(t, tree)
+	}
+}
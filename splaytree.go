@@ -4,37 +4,70 @@ import (
 	"cmp"
 )
 
+// splayTree is the splay tree implementation shared by SplayTree and TreeMap.
+type splayTree[E, V any] struct {
+	root    *bsNode[E, V]
+	compare func(a, b E) int
+	size    int
+	codec   Codec[E]
+}
+
 // SplayTree A splay tree where the most recently accessed bsNode is rotated to the root. A splay tree does
-// not have to be in strict balance.
-type SplayTree[E cmp.Ordered] struct {
-	root *bsNode[E]
+// not have to be in strict balance.  The zero value is an empty tree ordered by the natural order of E, when
+// E is a number or a string.
+type SplayTree[E any] struct {
+	splayTree[E, struct{}]
 }
 
 func NewSplayTree[E cmp.Ordered]() *SplayTree[E] {
-	return &SplayTree[E]{}
+	return NewSplayTreeFunc(cmp.Compare[E])
+}
+
+// NewSplayTreeFunc creates a splay tree ordered by compare, which returns a negative number when a < b,
+// a positive number when a > b and zero when a == b.
+func NewSplayTreeFunc[E any](compare func(a, b E) int) *SplayTree[E] {
+	t := new(SplayTree[E])
+	t.compare = compare
+	return t
 }
 
+// Insert Places a value into the tree, and splays on it.
+// Returns true if the value was inserted, false if the value exists already.
 func (t *SplayTree[E]) Insert(value E) bool {
-	node := &bsNode[E]{
+	_, inserted := t.insert(value)
+	return inserted
+}
+
+// Returns the bsNode holding value, and true if the bsNode was created by this call.  Either way the
+// bsNode is splayed to the root.
+func (t *splayTree[E, V]) insert(value E) (*bsNode[E, V], bool) {
+	if resolveCompare(&t.compare) == nil {
+		panic(ErrNoComparator)
+	}
+
+	node := &bsNode[E, V]{
 		value: value,
+		size:  1,
 	}
 
 	if t.root == nil {
 		t.root = node
-		return true
+		t.size++
+		return node, true
 	}
 
 	inserted := true
 	current := t.root
 	for {
-		if value < current.value {
+		x := t.compare(value, current.value)
+		if x < 0 {
 			if current.left == nil {
 				current.left = node
 				node.parent = current
 				break
 			}
 			current = current.left
-		} else if value > current.value {
+		} else if x > 0 {
 			if current.right == nil {
 				current.right = node
 				node.parent = current
@@ -42,24 +75,37 @@ func (t *SplayTree[E]) Insert(value E) bool {
 			}
 			current = current.right
 		} else {
+			node = current
 			inserted = false
 			break
 		}
 	}
 
+	if inserted {
+		t.size++
+	}
+	// splaying rotates every ancestor of the bsNode, which recomputes their subtree sizes
 	t.splay(node) // bring the newly inserted bsNode to the root
 
-	return inserted
+	return node, inserted
 }
 
 // Delete Remove nodes from the splay tree.
 // Based off the wikipedia description: https://en.wikipedia.org/wiki/Splay_tree#Deletion
 func (t *SplayTree[E]) Delete(value E) bool {
-	node := find(t.root, value)
+	return t.remove(value) != nil
+}
+
+// Unlinks the bsNode holding value from the tree, returns the removed bsNode or nil if value wasn't found.
+func (t *splayTree[E, V]) remove(value E) *bsNode[E, V] {
+	node := splayFind(t.root, value, t.compare)
+	if node == nil {
+		return nil
+	}
 	t.splay(node)
 
-	if node.value != value {
-		return false
+	if t.compare(node.value, value) != 0 {
+		return nil
 	}
 
 	left := node.left
@@ -69,11 +115,14 @@ func (t *SplayTree[E]) Delete(value E) bool {
 	node.left = nil
 	node.right = nil
 
+	t.size--
+
 	// connect the subtrees
-	var smax *bsNode[E] = nil
+	t.root = nil
+	var smax *bsNode[E, V] = nil
 	if left != nil {
 		left.parent = nil
-		smax = subtreeMax(left)
+		smax = rightmost[E](left).(*bsNode[E, V])
 		t.splay(smax)
 	}
 
@@ -81,40 +130,52 @@ func (t *SplayTree[E]) Delete(value E) bool {
 		right.parent = nil
 		if left != nil {
 			smax.right = right
+			smax.update()
 		} else {
 			t.root = right
 		}
 		right.parent = smax
 	}
 
-	return true
-}
-
-func subtreeMax[E cmp.Ordered](n *bsNode[E]) *bsNode[E] {
-	for n.right != nil {
-		n = n.right
-	}
-	return n
+	node.size = 1
+	return node
 }
 
 // Find - Returns true if the tree contains value.  Note that the tree will splay on the bsNode
 // containing the value, and in the case the value isn't found, on the leaf bsNode with the closest value.
 func (t *SplayTree[E]) Find(value E) bool {
-	node := splayFind(t.root, value)
+	return t.lookup(value) != nil
+}
+
+// Returns the bsNode holding value or nil, splaying on the last bsNode visited.
+func (t *splayTree[E, V]) lookup(value E) *bsNode[E, V] {
+	node := splayFind(t.root, value, t.compare)
+	if node == nil {
+		return nil
+	}
+
 	t.splay(node)
-	return node.value == value
+	if t.compare(node.value, value) != 0 {
+		return nil
+	}
+	return node
 }
 
-func (t *SplayTree[E]) Traverse(method func(node Node[E], v func(node Node[E]) bool) bool, v func(node Node[E]) bool) {
+func (t *splayTree[E, V]) Traverse(method func(node Node[E], v func(node Node[E]) bool) bool, v func(node Node[E]) bool) {
 	if t.root != nil {
 		method(t.root, v)
 	}
 }
 
 // common implementation between find and delete
-func splayFind[E cmp.Ordered](node *bsNode[E], value E) *bsNode[E] {
-	for node != nil && value != node.value {
-		if value < node.value {
+func splayFind[E, V any](node *bsNode[E, V], value E, compare func(a, b E) int) *bsNode[E, V] {
+	for node != nil {
+		x := compare(value, node.value)
+		if x == 0 {
+			break
+		}
+
+		if x < 0 {
 			if node.left == nil {
 				break
 			}
@@ -130,7 +191,7 @@ func splayFind[E cmp.Ordered](node *bsNode[E], value E) *bsNode[E] {
 }
 
 // rotate the tree until n is the root bsNode
-func (t *SplayTree[E]) splay(n *bsNode[E]) {
+func (t *splayTree[E, V]) splay(n *bsNode[E, V]) {
 	for n != t.root {
 		if n.parent == nil {
 			t.root = n
@@ -155,7 +216,7 @@ func (t *SplayTree[E]) splay(n *bsNode[E]) {
 	}
 }
 
-func (t *SplayTree[E]) trinodeLeft(n, p, gp *bsNode[E]) {
+func (t *splayTree[E, V]) trinodeLeft(n, p, gp *bsNode[E, V]) {
 	p.right = n.left
 	if n.left != nil {
 		n.left.parent = p
@@ -174,12 +235,16 @@ func (t *SplayTree[E]) trinodeLeft(n, p, gp *bsNode[E]) {
 	n.right = gp
 	n.left = p
 
+	gp.update()
+	p.update()
+	n.update()
+
 	if n.parent == nil {
 		t.root = n
 	}
 }
 
-func (t *SplayTree[E]) trinodeRight(n, p, gp *bsNode[E]) {
+func (t *splayTree[E, V]) trinodeRight(n, p, gp *bsNode[E, V]) {
 	p.left = n.right
 	if n.right != nil {
 		n.right.parent = p
@@ -198,13 +263,17 @@ func (t *SplayTree[E]) trinodeRight(n, p, gp *bsNode[E]) {
 	n.left = gp
 	n.right = p
 
+	gp.update()
+	p.update()
+	n.update()
+
 	if n.parent == nil {
 		t.root = n
 	}
 }
 
 // Restructure a left child of a left child to a right child of a right child, or vise versa.
-func (t *SplayTree[E]) zigzig(n *bsNode[E]) {
+func (t *splayTree[E, V]) zigzig(n *bsNode[E, V]) {
 	p := n.parent
 	gp := n.parent.parent
 
@@ -244,13 +313,17 @@ func (t *SplayTree[E]) zigzig(n *bsNode[E]) {
 		n.right = p
 	}
 
+	gp.update()
+	p.update()
+	n.update()
+
 	if n.parent == nil {
 		t.root = n
 	}
 }
 
 // Restructure a left child of a right child or vise versa.
-func (t *SplayTree[E]) zigzag(n *bsNode[E]) {
+func (t *splayTree[E, V]) zigzag(n *bsNode[E, V]) {
 	gp := n.parent.parent
 	p := n.parent
 
@@ -270,7 +343,7 @@ func (t *SplayTree[E]) zigzag(n *bsNode[E]) {
 	}
 }
 
-func (t *SplayTree[E]) rotateLeft(n *bsNode[E]) {
+func (t *splayTree[E, V]) rotateLeft(n *bsNode[E, V]) {
 	p := n.parent
 	n.parent = p.parent
 	if p.parent != nil {
@@ -291,9 +364,12 @@ func (t *SplayTree[E]) rotateLeft(n *bsNode[E]) {
 	}
 	p.parent = n
 	n.left = p
+
+	p.update()
+	n.update()
 }
 
-func (t *SplayTree[E]) rotateRight(n *bsNode[E]) {
+func (t *splayTree[E, V]) rotateRight(n *bsNode[E, V]) {
 	p := n.parent
 	n.parent = p.parent
 	if p.parent != nil {
@@ -314,4 +390,31 @@ func (t *SplayTree[E]) rotateRight(n *bsNode[E]) {
 	}
 	p.parent = n
 	n.right = p
+
+	p.update()
+	n.update()
+}
+
+func (t *splayTree[E, V]) rootNode() (Node[E], bool) {
+	return t.root, t.root != nil
+}
+
+func (t *splayTree[E, V]) removeValue(value E) bool {
+	return t.remove(value) != nil
+}
+
+// Len Returns the number of values in the tree.
+func (t *splayTree[E, V]) Len() int {
+	return t.size
+}
+
+// IsEmpty Returns true if the tree holds no values.
+func (t *splayTree[E, V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Clear Removes every value from the tree.
+func (t *splayTree[E, V]) Clear() {
+	t.root = nil
+	t.size = 0
 }
@@ -170,3 +170,38 @@ func TestDeleteInBigTree(t *testing.T) {
 	tree.Delete(3)
 	PrintTree[int](tree)
 }
+
+func TestInsertDuplicate(t *testing.T) {
+	expected := []int{25, 30, 50, 75}
+	tree := NewSplayTree[int]()
+	InsertAll(tree, 30, 25, 75, 50)
+
+	if tree.Insert(30) {
+		t.Error("inserted a duplicate value")
+	}
+
+	if tree.root.value != 30 {
+		t.Error("expected 30 to be splayed to the root, got", tree.root.value)
+	}
+
+	actual := make([]int, 0)
+	visitor := func(n Node[int]) bool {
+		actual = append(actual, n.Value())
+		return true
+	}
+	tree.Traverse(InOrder[int], visitor)
+	arrayEquals(t, "", expected, actual)
+}
+
+func TestDeleteOnlyNode(t *testing.T) {
+	tree := NewSplayTree[int]()
+	tree.Insert(42)
+	tree.Delete(42)
+
+	if tree.root != nil {
+		t.Error("expected an empty tree")
+	}
+	if tree.Delete(42) || tree.Find(42) {
+		t.Error("found 42 in an empty tree")
+	}
+}
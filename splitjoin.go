@@ -0,0 +1,245 @@
+package canopy
+
+import (
+	"errors"
+)
+
+// ErrOverlap is returned by Join when neither tree is entirely smaller than the other.
+var ErrOverlap = errors.New("canopy: the values of the joined trees overlap")
+
+// Split Moves the values greater than or equal to value into a new tree, leaving the smaller values in t.
+func (t *SplayTree[E]) Split(value E) *SplayTree[E] {
+	return &SplayTree[E]{*t.split(value)}
+}
+
+// Join Moves every value of other into t, leaving other empty.  Every value of other must be either
+// smaller or larger than every value of t, otherwise ErrOverlap is returned and neither tree is changed.
+func (t *SplayTree[E]) Join(other *SplayTree[E]) error {
+	return t.join(&other.splayTree)
+}
+
+func (t *splayTree[E, V]) split(value E) *splayTree[E, V] {
+	right := &splayTree[E, V]{compare: t.compare}
+	if t.root == nil {
+		return right
+	}
+
+	r := splayFind(t.root, value, t.compare)
+	t.splay(r)
+
+	// the root is now the closest value, if it's smaller than value then only its right subtree moves
+	if t.compare(r.value, value) < 0 {
+		right.root = r.right
+		r.right = nil
+	} else {
+		right.root = r
+		t.root = r.left
+		r.left = nil
+	}
+
+	if right.root != nil {
+		right.root.parent = nil
+		right.root.update()
+	}
+	if t.root != nil {
+		t.root.parent = nil
+		t.root.update()
+	}
+
+	right.size = right.root.count()
+	t.size = t.root.count()
+	return right
+}
+
+func (t *splayTree[E, V]) join(other *splayTree[E, V]) error {
+	if other.root == nil {
+		return nil
+	}
+	if t.root == nil {
+		t.root, t.size = other.root, other.size
+		if t.compare == nil {
+			t.compare = other.compare
+		}
+		other.Clear()
+		return nil
+	}
+
+	smaller, larger := t, other
+	if t.compare(rightmost[E](t.root).Value(), leftmost[E](other.root).Value()) >= 0 {
+		if t.compare(rightmost[E](other.root).Value(), leftmost[E](t.root).Value()) >= 0 {
+			return ErrOverlap
+		}
+		smaller, larger = other, t
+	}
+
+	// bring the largest value of the smaller tree to the root, it has no right child
+	smax := rightmost[E](smaller.root).(*bsNode[E, V])
+	smaller.splay(smax)
+	smax.right = larger.root
+	larger.root.parent = smax
+	smax.update()
+
+	t.root = smax
+	t.size = smax.size
+	other.Clear()
+	return nil
+}
+
+// Split Moves the values greater than or equal to value into a new tree, leaving the smaller values in t.
+func (t *RedBlackTree[E]) Split(value E) *RedBlackTree[E] {
+	return &RedBlackTree[E]{*t.split(value)}
+}
+
+// Join Moves every value of other into t, leaving other empty.  Every value of other must be either
+// smaller or larger than every value of t, otherwise ErrOverlap is returned and neither tree is changed.
+func (t *RedBlackTree[E]) Join(other *RedBlackTree[E]) error {
+	return t.join(&other.rbTree)
+}
+
+func (t *rbTree[E, V]) split(value E) *rbTree[E, V] {
+	left, right := t.splitNode(t.root, value)
+	t.root, t.size = left.root, left.size
+	return right
+}
+
+// Split the subtree rooted at n into trees of values less than value, and greater than or equal to value.
+func (t *rbTree[E, V]) splitNode(n *rbNode[E, V], value E) (*rbTree[E, V], *rbTree[E, V]) {
+	if n == nil {
+		return &rbTree[E, V]{compare: t.compare}, &rbTree[E, V]{compare: t.compare}
+	}
+
+	// n is used as the pivot to join the pieces back together
+	l, r := n.left, n.right
+	n.parent = nil
+	n.left = nil
+	n.right = nil
+	n.size = 1
+
+	if t.compare(value, n.value) <= 0 {
+		left, right := t.splitNode(l, value)
+		right.join3(n, t.subtree(r))
+		return left, right
+	}
+
+	left, right := t.splitNode(r, value)
+	sub := t.subtree(l)
+	sub.join3(n, left)
+	return sub, right
+}
+
+// Returns a tree rooted at n, which is detached from its parent and colored black.
+func (t *rbTree[E, V]) subtree(n *rbNode[E, V]) *rbTree[E, V] {
+	if n != nil {
+		n.parent = nil
+		n.color = Black
+	}
+	return &rbTree[E, V]{root: n, compare: t.compare, size: n.count()}
+}
+
+func (t *rbTree[E, V]) join(other *rbTree[E, V]) error {
+	if other.root == nil {
+		return nil
+	}
+	if t.root == nil {
+		t.root, t.size = other.root, other.size
+		if t.compare == nil {
+			t.compare = other.compare
+		}
+		other.Clear()
+		return nil
+	}
+
+	if t.compare(rightmost[E](t.root).Value(), leftmost[E](other.root).Value()) >= 0 {
+		if t.compare(rightmost[E](other.root).Value(), leftmost[E](t.root).Value()) >= 0 {
+			return ErrOverlap
+		}
+
+		// other is the smaller tree, join t onto it
+		t.root, other.root = other.root, t.root
+		t.size, other.size = other.size, t.size
+	}
+
+	// the smallest value of the larger tree becomes the pivot
+	pivot := other.remove(leftmost[E](other.root).Value())
+	t.join3(pivot, other)
+	other.Clear()
+	return nil
+}
+
+// Returns the number of black nodes on the path from n to its leftmost leaf, which is the
+// same for every path below n.
+func blackHeight[E, V any](n *rbNode[E, V]) int {
+	h := 0
+	for ; n != nil; n = n.left {
+		if n.color == Black {
+			h++
+		}
+	}
+	return h
+}
+
+// join3 joins t, the pivot k and other into t.  Every value in t must be less than k, and every value in
+// other greater than k.  The shorter tree is hung off the spine of the taller one at the node of equal
+// black height, then the red pivot is rebalanced in the same way as an insert.
+func (t *rbTree[E, V]) join3(k *rbNode[E, V], other *rbTree[E, V]) {
+	lh := blackHeight(t.root)
+	rh := blackHeight(other.root)
+	k.color = Red
+	size := t.size + other.size + 1
+
+	var parent *rbNode[E, V]
+	if lh >= rh {
+		// walk down the right spine of t to a black node with the same black height as other
+		cur, h := t.root, lh
+		for !(isBlack(cur) && h == rh) {
+			if isBlack(cur) {
+				h--
+			}
+			parent = cur
+			cur = cur.right
+		}
+
+		k.left = cur
+		k.right = other.root
+		if parent == nil {
+			t.root = k
+		} else {
+			parent.right = k
+		}
+	} else {
+		// walk down the left spine of other to a black node with the same black height as t
+		cur, h := other.root, rh
+		for !(isBlack(cur) && h == lh) {
+			if isBlack(cur) {
+				h--
+			}
+			parent = cur
+			cur = cur.left
+		}
+
+		k.right = cur
+		k.left = t.root
+		t.root = other.root
+		parent.left = k
+	}
+
+	k.parent = parent
+	if k.left != nil {
+		k.left.parent = k
+	}
+	if k.right != nil {
+		k.right.parent = k
+	}
+
+	for n := k; n != nil; n = n.parent {
+		n.update()
+	}
+
+	if parent == nil {
+		k.color = Black
+	} else {
+		t.balance(k)
+	}
+	t.root.color = Black
+	t.size = size
+}
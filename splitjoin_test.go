@@ -0,0 +1,121 @@
+package canopy
+
+import (
+	"slices"
+	"testing"
+)
+
+var splitValues = []int{
+	3, 36, 93, 61, 23, 83, 6, 25, 13, 66,
+	39, 63, 30, 20, 19, 21, 78, 72, 46, 40,
+	92, 84, 47, 24, 58, 89, 96, 26, 53, 98,
+}
+
+func TestRedBlack_split(t *testing.T) {
+	sorted := slices.Sorted(slices.Values(splitValues))
+	for _, at := range []int{0, 3, 4, 30, 50, 98, 99, 200} {
+		tree := NewRedBlackTree[int]()
+		InsertAll(tree, splitValues...)
+
+		right := tree.Split(at)
+		checkRedBlack(t, tree)
+		checkRedBlack(t, right)
+
+		i, _ := slices.BinarySearch(sorted, at)
+		arrayEquals(t, "left", sorted[:i], slices.Collect(tree.All()))
+		arrayEquals(t, "right", sorted[i:], slices.Collect(right.All()))
+		if tree.Len() != i || right.Len() != len(sorted)-i {
+			t.Error("split at", at, "has sizes", tree.Len(), right.Len())
+		}
+
+		if err := tree.Join(right); err != nil {
+			t.Fatal(err)
+		}
+		checkRedBlack(t, tree)
+		arrayEquals(t, "joined", sorted, slices.Collect(tree.All()))
+		if tree.Len() != len(sorted) || !right.IsEmpty() {
+			t.Error("join at", at, "has sizes", tree.Len(), right.Len())
+		}
+	}
+}
+
+func TestRedBlack_joinUneven(t *testing.T) {
+	small := NewRedBlackTree[int]()
+	InsertAll(small, 1, 2)
+
+	large := NewRedBlackTree[int]()
+	for i := 10; i < 500; i++ {
+		large.Insert(i)
+	}
+
+	// the larger values are in the receiver
+	if err := large.Join(small); err != nil {
+		t.Fatal(err)
+	}
+	checkRedBlack(t, large)
+
+	expected := []int{1, 2}
+	for i := 10; i < 500; i++ {
+		expected = append(expected, i)
+	}
+	arrayEquals(t, "", expected, slices.Collect(large.All()))
+
+	if v, ok := large.Select(2); !ok || v != 10 {
+		t.Error("expected 10 at index 2, got", v, ok)
+	}
+}
+
+func TestRedBlack_joinOverlap(t *testing.T) {
+	a := NewRedBlackTree[int]()
+	InsertAll(a, 1, 5, 10)
+	b := NewRedBlackTree[int]()
+	InsertAll(b, 3, 20)
+
+	if err := a.Join(b); err != ErrOverlap {
+		t.Error("expected ErrOverlap, got", err)
+	}
+	arrayEquals(t, "", []int{1, 5, 10}, slices.Collect(a.All()))
+	arrayEquals(t, "", []int{3, 20}, slices.Collect(b.All()))
+}
+
+func TestSplay_split(t *testing.T) {
+	sorted := slices.Sorted(slices.Values(splitValues))
+	for _, at := range []int{0, 3, 4, 30, 50, 98, 99, 200} {
+		tree := NewSplayTree[int]()
+		InsertAll(tree, splitValues...)
+
+		right := tree.Split(at)
+		i, _ := slices.BinarySearch(sorted, at)
+		arrayEquals(t, "left", sorted[:i], slices.Collect(tree.All()))
+		arrayEquals(t, "right", sorted[i:], slices.Collect(right.All()))
+		if tree.Len() != i || right.Len() != len(sorted)-i {
+			t.Error("split at", at, "has sizes", tree.Len(), right.Len())
+		}
+
+		// join the smaller tree onto the larger one
+		if err := right.Join(tree); err != nil {
+			t.Fatal(err)
+		}
+		arrayEquals(t, "joined", sorted, slices.Collect(right.All()))
+		if right.Len() != len(sorted) || !tree.IsEmpty() {
+			t.Error("join at", at, "has sizes", right.Len(), tree.Len())
+		}
+
+		for k, v := range sorted {
+			if actual, ok := right.Select(k); !ok || actual != v {
+				t.Error("expected", v, "at", k, "got", actual, ok)
+			}
+		}
+	}
+}
+
+func TestSplay_joinOverlap(t *testing.T) {
+	a := NewSplayTree[int]()
+	InsertAll(a, 1, 5, 10)
+	b := NewSplayTree[int]()
+	InsertAll(b, 3, 20)
+
+	if err := a.Join(b); err != ErrOverlap {
+		t.Error("expected ErrOverlap, got", err)
+	}
+}
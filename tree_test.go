@@ -1,7 +1,9 @@
 package canopy
 
 import (
+	"cmp"
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -16,3 +18,243 @@ func TestTraversal(t *testing.T) {
 
 	tree.Traverse(PostOrder[int], foo)
 }
+
+func TestCompareFunc(t *testing.T) {
+	type event struct {
+		name string
+		at   int
+	}
+	byTime := func(a, b event) int {
+		return cmp.Compare(a.at, b.at)
+	}
+
+	trees := map[string]Tree[event]{
+		"bst":      NewBinarySearchTreeFunc(byTime),
+		"splay":    NewSplayTreeFunc(byTime),
+		"redblack": NewRedBlackTreeFunc(byTime),
+		"avl":      NewAVLTreeFunc(byTime),
+	}
+
+	for name, tree := range trees {
+		InsertAll(tree, event{"c", 3}, event{"a", 1}, event{"d", 4}, event{"b", 2})
+
+		if tree.Insert(event{"other", 3}) {
+			t.Error(name, "inserted an event with a duplicate time")
+		}
+
+		if !tree.Find(event{at: 4}) {
+			t.Error(name, "could not find an event at 4")
+		}
+
+		names := ""
+		visitor := func(n Node[event]) bool {
+			names += n.Value().name
+			return true
+		}
+		tree.Traverse(InOrder[event], visitor)
+		if names != "abcd" {
+			t.Error(name, "expected events in time order, got", names)
+		}
+
+		if !tree.Delete(event{at: 2}) || tree.Find(event{at: 2}) {
+			t.Error(name, "failed to delete the event at 2")
+		}
+	}
+}
+
+func TestCompareFuncMap(t *testing.T) {
+	m := NewRedBlackTreeMapFunc[string, int](func(a, b string) int {
+		return cmp.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	m.Put("Apple", 1)
+	m.Put("apple", 2)
+
+	if v, ok := m.Get("APPLE"); !ok || v != 2 {
+		t.Error("expected a case insensitive match, got", v, ok)
+	}
+}
+
+func TestLen(t *testing.T) {
+	for name, tree := range allTrees() {
+		if !tree.IsEmpty() || tree.Len() != 0 {
+			t.Error(name, "new tree is not empty")
+		}
+
+		InsertAll(tree, 30, 10, 50, 20, 40, 30, 10)
+		if tree.Len() != 5 {
+			t.Error(name, "expected 5 values, got", tree.Len())
+		}
+
+		tree.Delete(30)
+		tree.Delete(30)
+		tree.Delete(99)
+		if tree.Len() != 4 || tree.IsEmpty() {
+			t.Error(name, "expected 4 values, got", tree.Len())
+		}
+
+		tree.Clear()
+		if !tree.IsEmpty() || tree.Find(10) {
+			t.Error(name, "tree is not empty after Clear")
+		}
+
+		tree.Insert(1)
+		if tree.Len() != 1 {
+			t.Error(name, "expected 1 value after Clear and Insert, got", tree.Len())
+		}
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	var bst BSTree[int]
+	var splay SplayTree[int]
+	var redBlack RedBlackTree[int]
+	var avl AVLTree[int]
+	trees := map[string]Tree[int]{"bst": &bst, "splay": &splay, "redblack": &redBlack, "avl": &avl}
+
+	for name, tree := range trees {
+		if tree.Find(10) || tree.Delete(10) {
+			t.Error(name, "found a value in an empty tree")
+		}
+
+		InsertAll(tree, 30, 10, 50, 20, 40)
+		if !tree.Find(20) || tree.Find(25) {
+			t.Error(name, "lookups failed on a zero value tree")
+		}
+		if !tree.Delete(30) || tree.Delete(30) || tree.Len() != 4 {
+			t.Error(name, "delete failed on a zero value tree")
+		}
+		if err := tree.(interface{ Validate() error }).Validate(); err != nil {
+			t.Error(name, err)
+		}
+	}
+
+	type name string
+	var names RedBlackTree[name]
+	InsertAll[name](&names, "b", "c", "a")
+	if first, _ := names.Min(); first != "a" {
+		t.Error("expected a named string type in its natural order, got", first)
+	}
+
+	defer func() {
+		if recover() != ErrNoComparator {
+			t.Error("expected a zero value tree of an unordered type to panic with ErrNoComparator")
+		}
+	}()
+	var points BSTree[struct{ X, Y int }]
+	points.Insert(struct{ X, Y int }{1, 2})
+}
+
+func TestLenMap(t *testing.T) {
+	m := NewSplayTreeMap[int, int]()
+	m.Put(1, 1)
+	m.Put(1, 2)
+	m.Put(2, 2)
+	if m.Len() != 2 {
+		t.Error("expected 2 entries, got", m.Len())
+	}
+
+	m.Remove(1)
+	if m.Len() != 1 {
+		t.Error("expected 1 entry, got", m.Len())
+	}
+
+	m.Clear()
+	if !m.IsEmpty() {
+		t.Error("map is not empty after Clear")
+	}
+}
+
+// height computed only through the exported Node accessors
+func nodeHeight[E any](n Node[E]) int {
+	h := 0
+	if left, ok := n.Left(); ok {
+		h = nodeHeight(left)
+	}
+	if right, ok := n.Right(); ok {
+		h = max(h, nodeHeight(right))
+	}
+	return h + 1
+}
+
+func TestNodeAccessors(t *testing.T) {
+	tree := NewRedBlackTree[int]()
+	InsertAll(tree, 32, 42, 52, 62)
+
+	tree.Traverse(PreOrder[int], func(n Node[int]) bool {
+		if parent, ok := n.Parent(); ok {
+			left, hasLeft := parent.Left()
+			right, hasRight := parent.Right()
+			if !(hasLeft && left == n || hasRight && right == n) {
+				t.Error(n.Value(), "is not a child of its parent")
+			}
+		} else if n.Value() != 42 {
+			t.Error("expected 42 at the root, got", n.Value())
+		}
+
+		_, hasLeft := n.Left()
+		_, hasRight := n.Right()
+		if n.IsLeaf() != (!hasLeft && !hasRight) {
+			t.Error(n.Value(), "IsLeaf is wrong")
+		}
+
+		colored, ok := n.(ColoredNode[int])
+		if !ok {
+			t.Fatal("red black nodes should have a color")
+		}
+		if n.Value() == 62 && colored.Color() != Red || n.Value() == 42 && colored.Color() != Black {
+			t.Error(n.Value(), "has the wrong color", colored.Color())
+		}
+		return true
+	})
+
+	tree.Traverse(func(root Node[int], _ func(node Node[int]) bool) bool {
+		if h := nodeHeight(root); h != 3 {
+			t.Error("expected height 3, got", h)
+		}
+		return true
+	}, nil)
+}
+
+// plainNode implements Node with only the exported methods, as a node from another package would.
+type plainNode struct {
+	value               int
+	parent, left, right *plainNode
+}
+
+func (n *plainNode) Value() int {
+	return n.value
+}
+
+func (n *plainNode) Parent() (Node[int], bool) {
+	return n.parent, n.parent != nil
+}
+
+func (n *plainNode) Left() (Node[int], bool) {
+	return n.left, n.left != nil
+}
+
+func (n *plainNode) Right() (Node[int], bool) {
+	return n.right, n.right != nil
+}
+
+func (n *plainNode) IsLeaf() bool {
+	return n.left == nil && n.right == nil
+}
+
+func TestForeignNode(t *testing.T) {
+	root := &plainNode{value: 2}
+	root.left = &plainNode{value: 1, parent: root}
+	root.right = &plainNode{value: 3, parent: root}
+
+	var n Node[int] = root
+	if subtreeSize(n) != 3 {
+		t.Error("expected a subtree size of 3, got", subtreeSize(n))
+	}
+
+	values := make([]int, 0)
+	StacklessInOrder(n, func(n Node[int]) bool {
+		values = append(values, n.Value())
+		return true
+	})
+	arrayEquals(t, "foreign in order", []int{1, 2, 3}, values)
+}
@@ -0,0 +1,212 @@
+package canopy
+
+import (
+	"cmp"
+)
+
+// MapNode is the Node type of a TreeMap.  Value and Key both return the key the node is ordered by,
+// MapValue returns the value stored alongside the key.
+type MapNode[K, V any] interface {
+	Node[K]
+	Key() K
+	MapValue() V
+}
+
+// entryTree is implemented by each tree type so it can store the entries of a TreeMap.
+type entryTree[K, V any] interface {
+	put(key K, value V) bool
+	get(key K) (V, bool)
+	take(key K) (V, bool)
+	Len() int
+	Clear()
+	traverser[K]
+}
+
+// TreeMap an ordered map of keys to values.  The balancing strategy is picked by the constructor.
+type TreeMap[K, V any] struct {
+	tree entryTree[K, V]
+}
+
+// NewBSTreeMap creates a TreeMap backed by an unbalanced binary search tree.
+func NewBSTreeMap[K cmp.Ordered, V any]() *TreeMap[K, V] {
+	return NewBSTreeMapFunc[K, V](cmp.Compare[K])
+}
+
+// NewBSTreeMapFunc creates a TreeMap backed by an unbalanced binary search tree, with keys ordered by compare.
+func NewBSTreeMapFunc[K, V any](compare func(a, b K) int) *TreeMap[K, V] {
+	return &TreeMap[K, V]{tree: &bsTree[K, V]{compare: compare}}
+}
+
+// NewSplayTreeMap creates a TreeMap backed by a splay tree.
+func NewSplayTreeMap[K cmp.Ordered, V any]() *TreeMap[K, V] {
+	return NewSplayTreeMapFunc[K, V](cmp.Compare[K])
+}
+
+// NewSplayTreeMapFunc creates a TreeMap backed by a splay tree, with keys ordered by compare.
+func NewSplayTreeMapFunc[K, V any](compare func(a, b K) int) *TreeMap[K, V] {
+	return &TreeMap[K, V]{tree: &splayTree[K, V]{compare: compare}}
+}
+
+// NewRedBlackTreeMap creates a TreeMap backed by a red black tree.
+func NewRedBlackTreeMap[K cmp.Ordered, V any]() *TreeMap[K, V] {
+	return NewRedBlackTreeMapFunc[K, V](cmp.Compare[K])
+}
+
+// NewRedBlackTreeMapFunc creates a TreeMap backed by a red black tree, with keys ordered by compare.
+func NewRedBlackTreeMapFunc[K, V any](compare func(a, b K) int) *TreeMap[K, V] {
+	return &TreeMap[K, V]{tree: &rbTree[K, V]{compare: compare}}
+}
+
+// NewAVLTreeMap creates a TreeMap backed by an AVL tree.
+func NewAVLTreeMap[K cmp.Ordered, V any]() *TreeMap[K, V] {
+	return NewAVLTreeMapFunc[K, V](cmp.Compare[K])
+}
+
+// NewAVLTreeMapFunc creates a TreeMap backed by an AVL tree, with keys ordered by compare.
+func NewAVLTreeMapFunc[K, V any](compare func(a, b K) int) *TreeMap[K, V] {
+	return &TreeMap[K, V]{tree: &avlTree[K, V]{compare: compare}}
+}
+
+// Put Associates value with key.
+// Returns true if the key was added, false if the value of an existing key was replaced.
+func (m *TreeMap[K, V]) Put(key K, value V) bool {
+	return m.tree.put(key, value)
+}
+
+// Get Returns the value associated with key, and true if the key exists.
+func (m *TreeMap[K, V]) Get(key K) (V, bool) {
+	return m.tree.get(key)
+}
+
+// Remove Deletes key from the map.
+// Returns the value that was associated with key, and true if the key existed.
+func (m *TreeMap[K, V]) Remove(key K) (V, bool) {
+	return m.tree.take(key)
+}
+
+// ContainsKey Returns true if the key exists in the map.
+func (m *TreeMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.tree.get(key)
+	return ok
+}
+
+// Len Returns the number of entries in the map.
+func (m *TreeMap[K, V]) Len() int {
+	return m.tree.Len()
+}
+
+// IsEmpty Returns true if the map has no entries.
+func (m *TreeMap[K, V]) IsEmpty() bool {
+	return m.tree.Len() == 0
+}
+
+// Clear Removes every entry from the map.
+func (m *TreeMap[K, V]) Clear() {
+	m.tree.Clear()
+}
+
+// Traverse provides a way to visit the nodes of the map, each node is a MapNode.
+func (m *TreeMap[K, V]) Traverse(method func(node Node[K], v func(node Node[K]) bool) bool, v func(node Node[K]) bool) {
+	m.tree.Traverse(method, v)
+}
+
+// Each visits the entries of the map in key order, until visit returns false.
+func (m *TreeMap[K, V]) Each(visit func(key K, value V) bool) {
+	m.All()(visit)
+}
+
+func (t *bsTree[E, V]) put(key E, value V) bool {
+	n, added := t.insert(key)
+	n.payload = value
+	return added
+}
+
+func (t *bsTree[E, V]) get(key E) (V, bool) {
+	n := find(t.root, key, t.compare)
+	if n == nil {
+		var zero V
+		return zero, false
+	}
+	return n.payload, true
+}
+
+func (t *bsTree[E, V]) take(key E) (V, bool) {
+	n := t.remove(key)
+	if n == nil {
+		var zero V
+		return zero, false
+	}
+	return n.payload, true
+}
+
+func (t *splayTree[E, V]) put(key E, value V) bool {
+	n, added := t.insert(key)
+	n.payload = value
+	return added
+}
+
+func (t *splayTree[E, V]) get(key E) (V, bool) {
+	n := t.lookup(key)
+	if n == nil {
+		var zero V
+		return zero, false
+	}
+	return n.payload, true
+}
+
+func (t *splayTree[E, V]) take(key E) (V, bool) {
+	n := t.remove(key)
+	if n == nil {
+		var zero V
+		return zero, false
+	}
+	return n.payload, true
+}
+
+func (t *rbTree[E, V]) put(key E, value V) bool {
+	n, added := t.insert(key)
+	n.payload = value
+	return added
+}
+
+func (t *rbTree[E, V]) get(key E) (V, bool) {
+	n := rbfind(t.root, key, t.compare)
+	if n == nil {
+		var zero V
+		return zero, false
+	}
+	return n.payload, true
+}
+
+func (t *rbTree[E, V]) take(key E) (V, bool) {
+	n := t.remove(key)
+	if n == nil {
+		var zero V
+		return zero, false
+	}
+	return n.payload, true
+}
+
+func (t *avlTree[E, V]) put(key E, value V) bool {
+	n, added := t.insert(key)
+	n.payload = value
+	return added
+}
+
+func (t *avlTree[E, V]) get(key E) (V, bool) {
+	n := avlFind(t.root, key, t.compare)
+	if n == nil {
+		var zero V
+		return zero, false
+	}
+	return n.payload, true
+}
+
+func (t *avlTree[E, V]) take(key E) (V, bool) {
+	n := t.remove(key)
+	if n == nil {
+		var zero V
+		return zero, false
+	}
+	return n.payload, true
+}
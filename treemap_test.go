@@ -0,0 +1,113 @@
+package canopy
+
+import (
+	"fmt"
+	"testing"
+)
+
+var treeMapConstructors = map[string]func() *TreeMap[int, string]{
+	"bst":      NewBSTreeMap[int, string],
+	"splay":    NewSplayTreeMap[int, string],
+	"redblack": NewRedBlackTreeMap[int, string],
+	"avl":      NewAVLTreeMap[int, string],
+}
+
+func TestTreeMap_putGet(t *testing.T) {
+	for name, newMap := range treeMapConstructors {
+		m := newMap()
+		keys := []int{50, 20, 80, 10, 30, 70, 90}
+		for _, k := range keys {
+			if !m.Put(k, fmt.Sprint("v", k)) {
+				t.Error(name, "put of new key", k, "returned false")
+			}
+		}
+
+		if m.Put(20, "twenty") {
+			t.Error(name, "put of existing key returned true")
+		}
+
+		for _, k := range keys {
+			expected := fmt.Sprint("v", k)
+			if k == 20 {
+				expected = "twenty"
+			}
+
+			actual, ok := m.Get(k)
+			if !ok || actual != expected {
+				t.Error(name, "expected", expected, "got", actual, ok)
+			}
+
+			if !m.ContainsKey(k) {
+				t.Error(name, "missing key", k)
+			}
+		}
+
+		if _, ok := m.Get(42); ok {
+			t.Error(name, "found a key that was never added")
+		}
+	}
+}
+
+func TestTreeMap_remove(t *testing.T) {
+	for name, newMap := range treeMapConstructors {
+		m := newMap()
+		for _, k := range []int{50, 20, 80, 10, 30, 70, 90} {
+			m.Put(k, fmt.Sprint("v", k))
+		}
+
+		for _, k := range []int{50, 10, 90} {
+			actual, ok := m.Remove(k)
+			if !ok || actual != fmt.Sprint("v", k) {
+				t.Error(name, "remove", k, "returned", actual, ok)
+			}
+			if m.ContainsKey(k) {
+				t.Error(name, "found", k, "after remove")
+			}
+		}
+
+		if _, ok := m.Remove(50); ok {
+			t.Error(name, "removed a key twice")
+		}
+
+		// the remaining values must still be attached to the right keys
+		for _, k := range []int{20, 30, 70, 80} {
+			if actual, ok := m.Get(k); !ok || actual != fmt.Sprint("v", k) {
+				t.Error(name, "expected", fmt.Sprint("v", k), "got", actual, ok)
+			}
+		}
+	}
+}
+
+func TestTreeMap_each(t *testing.T) {
+	for name, newMap := range treeMapConstructors {
+		m := newMap()
+		for _, k := range []int{3, 1, 2, 5, 4} {
+			m.Put(k, fmt.Sprint("v", k))
+		}
+
+		keys := make([]int, 0)
+		m.Each(func(k int, v string) bool {
+			if v != fmt.Sprint("v", k) {
+				t.Error(name, "key", k, "has value", v)
+			}
+			keys = append(keys, k)
+			return k < 4
+		})
+		arrayEquals(t, name, []int{1, 2, 3, 4}, keys)
+	}
+}
+
+func TestTreeMap_traverse(t *testing.T) {
+	m := NewRedBlackTreeMap[int, string]()
+	m.Put(2, "two")
+	m.Put(1, "one")
+	m.Put(3, "three")
+
+	values := make([]string, 0)
+	v := func(n Node[int]) bool {
+		values = append(values, n.(MapNode[int, string]).MapValue())
+		return true
+	}
+	m.Traverse(PreOrder[int], v)
+	arrayEquals(t, "", []string{"two", "one", "three"}, values)
+}
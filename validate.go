@@ -0,0 +1,168 @@
+package canopy
+
+import (
+	"fmt"
+)
+
+// Check the binary search tree properties of t: values are ordered and unique, children point back to
+// their parent, there are no cycles, subtree sizes are correct and the tree holds size nodes.
+func validate[E any](t searchTree[E], size int) error {
+	root, ok := t.rootNode()
+	if !ok {
+		if size != 0 {
+			return fmt.Errorf("canopy: empty tree has a size of %d", size)
+		}
+		return nil
+	}
+	compare := t.comparator()
+
+	if parent, ok := root.Parent(); ok {
+		return fmt.Errorf("canopy: root %v has parent %v", root.Value(), parent.Value())
+	}
+
+	// lo and hi are the nearest ancestors the node must be greater and less than
+	type frame struct {
+		n, lo, hi Node[E]
+	}
+
+	seen := make(map[Node[E]]bool)
+	stack := []frame{{n: root}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		n := f.n
+
+		if seen[n] {
+			return fmt.Errorf("canopy: cycle at %v", n.Value())
+		}
+		seen[n] = true
+
+		if f.lo != nil && compare(n.Value(), f.lo.Value()) <= 0 {
+			return fmt.Errorf("canopy: %v is not greater than its ancestor %v", n.Value(), f.lo.Value())
+		}
+		if f.hi != nil && compare(n.Value(), f.hi.Value()) >= 0 {
+			return fmt.Errorf("canopy: %v is not less than its ancestor %v", n.Value(), f.hi.Value())
+		}
+
+		expected := 1
+		if left, ok := n.Left(); ok {
+			if parent, ok := left.Parent(); !ok || parent != n {
+				return fmt.Errorf("canopy: left child %v of %v does not point back to its parent", left.Value(), n.Value())
+			}
+			expected += subtreeSize(left)
+			stack = append(stack, frame{left, f.lo, n})
+		}
+		if right, ok := n.Right(); ok {
+			if parent, ok := right.Parent(); !ok || parent != n {
+				return fmt.Errorf("canopy: right child %v of %v does not point back to its parent", right.Value(), n.Value())
+			}
+			expected += subtreeSize(right)
+			stack = append(stack, frame{right, n, f.hi})
+		}
+
+		if subtreeSize(n) != expected {
+			return fmt.Errorf("canopy: %v has a subtree size of %d, expected %d", n.Value(), subtreeSize(n), expected)
+		}
+	}
+
+	if len(seen) != size {
+		return fmt.Errorf("canopy: tree has %d nodes, expected %d", len(seen), size)
+	}
+	return nil
+}
+
+// Validate Returns an error describing the first broken binary search tree property found, or nil.
+func (t *bsTree[E, V]) Validate() error {
+	return validate[E](t, t.size)
+}
+
+// Validate Returns an error describing the first broken binary search tree property found, or nil.
+// Validate does not splay.
+func (t *splayTree[E, V]) Validate() error {
+	return validate[E](t, t.size)
+}
+
+// Validate Returns an error describing the first broken binary search tree or red black property found,
+// or nil.
+func (t *rbTree[E, V]) Validate() error {
+	if err := validate[E](t, t.size); err != nil {
+		return err
+	}
+	if t.root == nil {
+		return nil
+	}
+
+	if t.root.color != Black {
+		return fmt.Errorf("canopy: root %v is not black", t.root.value)
+	}
+
+	// blacks counts the black nodes on the path from the root to n
+	type frame struct {
+		n      *rbNode[E, V]
+		blacks int
+	}
+
+	height := -1
+	stack := []frame{{t.root, 1}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		n := f.n
+
+		if n.color == Red && (!isBlack(n.left) || !isBlack(n.right)) {
+			return fmt.Errorf("canopy: red node %v has a red child", n.value)
+		}
+
+		if n.left == nil || n.right == nil {
+			if height == -1 {
+				height = f.blacks
+			} else if f.blacks != height {
+				return fmt.Errorf("canopy: path through %v has %d black nodes, expected %d", n.value, f.blacks, height)
+			}
+		}
+
+		for _, c := range []*rbNode[E, V]{n.left, n.right} {
+			if c == nil {
+				continue
+			}
+
+			blacks := f.blacks
+			if c.color == Black {
+				blacks++
+			}
+			stack = append(stack, frame{c, blacks})
+		}
+	}
+	return nil
+}
+
+// Validate Returns an error describing the first broken binary search tree or AVL property found, or nil.
+func (t *avlTree[E, V]) Validate() error {
+	if err := validate[E](t, t.size); err != nil {
+		return err
+	}
+	if t.root == nil {
+		return nil
+	}
+
+	stack := []*avlNode[E, V]{t.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if h := 1 + max(avlHeight(n.left), avlHeight(n.right)); n.height != h {
+			return fmt.Errorf("canopy: %v has a height of %d, expected %d", n.value, n.height, h)
+		}
+		if bf := n.balanceFactor(); bf < -1 || bf > 1 {
+			return fmt.Errorf("canopy: %v is out of balance by %d", n.value, bf)
+		}
+
+		if n.left != nil {
+			stack = append(stack, n.left)
+		}
+		if n.right != nil {
+			stack = append(stack, n.right)
+		}
+	}
+	return nil
+}
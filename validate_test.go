@@ -0,0 +1,117 @@
+package canopy
+
+import (
+	"strings"
+	"testing"
+)
+
+// validTree is the part of a tree tested for the invariant checks.
+type validTree[E any] interface {
+	Tree[E]
+	Validate() error
+}
+
+func TestValidate(t *testing.T) {
+	for name, tree := range treesAs[validTree[int]](t) {
+		if err := tree.Validate(); err != nil {
+			t.Error(name, "empty tree:", err)
+		}
+
+		for _, v := range orderStatValues {
+			tree.Insert(v)
+			if err := tree.Validate(); err != nil {
+				t.Fatal(name, "after inserting", v, ":", err)
+			}
+		}
+		for i := 0; i < len(orderStatValues); i += 2 {
+			tree.Delete(orderStatValues[i])
+			if err := tree.Validate(); err != nil {
+				t.Fatal(name, "after deleting", orderStatValues[i], ":", err)
+			}
+		}
+	}
+}
+
+// expectInvalid checks that Validate fails and names value.
+func expectInvalid(t *testing.T, name string, err error, value string) {
+	t.Helper()
+	if err == nil {
+		t.Error(name, "expected an error")
+	} else if !strings.Contains(err.Error(), value) {
+		t.Error(name, "expected the error to name", value, "got", err)
+	}
+}
+
+func TestValidateOrder(t *testing.T) {
+	tree := NewBinarySearchTree[int]()
+	InsertAll[int](tree, 50, 30, 70, 20, 40)
+
+	// 40 is in the left subtree of 50, so 55 is out of order even though it is greater than its parent 30
+	tree.root.left.right.value = 55
+	expectInvalid(t, "order", tree.Validate(), "55")
+}
+
+func TestValidateParent(t *testing.T) {
+	tree := NewSplayTree[int]()
+	InsertAll[int](tree, 50, 30, 70, 20, 40)
+
+	n := tree.root.right
+	n.parent = nil
+	expectInvalid(t, "parent", tree.Validate(), "70")
+}
+
+func TestValidateCycle(t *testing.T) {
+	tree := NewBinarySearchTree[int]()
+	InsertAll[int](tree, 50, 30, 70)
+
+	// point 70 back at the root without breaking the parent check of its own children
+	tree.root.right.right = tree.root
+	expectInvalid(t, "cycle", tree.Validate(), "50")
+}
+
+func TestValidateSize(t *testing.T) {
+	tree := NewBinarySearchTree[int]()
+	InsertAll[int](tree, 50, 30, 70)
+
+	tree.root.left.size = 3
+	expectInvalid(t, "subtree size", tree.Validate(), "50")
+
+	tree.root.left.size = 1
+	tree.size = 4
+	if err := tree.Validate(); err == nil {
+		t.Error("expected an error for a wrong tree size")
+	}
+}
+
+func TestValidateRedBlack(t *testing.T) {
+	tree := NewRedBlackTree[int]()
+	InsertAll[int](tree, 50, 30, 70, 20)
+
+	// 20 is a red child of the black 30
+	tree.root.left.color = Red
+	expectInvalid(t, "red red", tree.Validate(), "30")
+
+	tree.root.left.color = Black
+	tree.root.left.left.color = Black
+	expectInvalid(t, "black height", tree.Validate(), "20")
+
+	tree.root.left.left.color = Red
+	tree.root.color = Red
+	expectInvalid(t, "red root", tree.Validate(), "50")
+}
+
+func TestValidateAVL(t *testing.T) {
+	tree := NewAVLTree[int]()
+	InsertAll[int](tree, 50, 30, 70, 20)
+
+	// the stored height of 20 is wrong, which its parent 30 no longer agrees with
+	tree.root.left.left.height = 5
+	expectInvalid(t, "height", tree.Validate(), "30")
+
+	tree.root.left.left.height = 1
+	tree.root.height = 3
+	tree.root.right = nil
+	tree.size--
+	tree.root.size--
+	expectInvalid(t, "balance", tree.Validate(), "50")
+}
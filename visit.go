@@ -0,0 +1,171 @@
+package canopy
+
+// Side identifies which child of its parent a node is.
+type Side uint8
+
+const (
+	RootSide Side = iota
+	LeftSide
+	RightSide
+)
+
+func (s Side) String() string {
+	switch s {
+	case LeftSide:
+		return "left"
+	case RightSide:
+		return "right"
+	}
+	return "root"
+}
+
+// Visit describes a node and where it sits in the tree.
+type Visit[E any] struct {
+	Node Node[E]
+
+	// Depth is the distance from the root, the root has a depth of zero.
+	Depth int
+
+	// Index is the position of the node among the nodes at the same depth, counting from zero on the left.
+	Index int
+
+	// Side is the side of its parent the node hangs from.
+	Side Side
+
+	// Parent is the parent of the node, or nil for the root.
+	Parent Node[E]
+}
+
+// TraverseVisit traverses tree with one of the Visit traversals, such as InOrderVisit.
+func TraverseVisit[E any](tree Tree[E], method func(node Node[E], v func(visit Visit[E]) bool) bool, v func(visit Visit[E]) bool) {
+	tree.Traverse(func(root Node[E], _ func(node Node[E]) bool) bool {
+		return method(root, v)
+	}, nil)
+}
+
+// Tracks the number of nodes visited at each depth during a traversal.
+type visitor[E any] struct {
+	counts []int
+	v      func(visit Visit[E]) bool
+}
+
+func (s *visitor[E]) visit(n Node[E], depth int, side Side, parent Node[E]) bool {
+	for depth >= len(s.counts) {
+		s.counts = append(s.counts, 0)
+	}
+
+	index := s.counts[depth]
+	s.counts[depth]++
+	return s.v(Visit[E]{Node: n, Depth: depth, Index: index, Side: side, Parent: parent})
+}
+
+// PreOrderVisit recursively traverses a binary tree in pre order, passing the position of each node.
+func PreOrderVisit[E any](node Node[E], v func(visit Visit[E]) bool) bool {
+	s := &visitor[E]{v: v}
+	return s.preOrder(node, 0, RootSide, nil)
+}
+
+func (s *visitor[E]) preOrder(n Node[E], depth int, side Side, parent Node[E]) bool {
+	if !s.visit(n, depth, side, parent) {
+		return false
+	}
+
+	if left, ok := n.Left(); ok {
+		if !s.preOrder(left, depth+1, LeftSide, n) {
+			return false
+		}
+	}
+
+	if right, ok := n.Right(); ok {
+		if !s.preOrder(right, depth+1, RightSide, n) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// InOrderVisit recursively traverses a binary tree "in order", passing the position of each node.
+func InOrderVisit[E any](node Node[E], v func(visit Visit[E]) bool) bool {
+	s := &visitor[E]{v: v}
+	return s.inOrder(node, 0, RootSide, nil)
+}
+
+func (s *visitor[E]) inOrder(n Node[E], depth int, side Side, parent Node[E]) bool {
+	if left, ok := n.Left(); ok {
+		if !s.inOrder(left, depth+1, LeftSide, n) {
+			return false
+		}
+	}
+
+	if !s.visit(n, depth, side, parent) {
+		return false
+	}
+
+	if right, ok := n.Right(); ok {
+		if !s.inOrder(right, depth+1, RightSide, n) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// PostOrderVisit recursively traverses a binary tree in post order, passing the position of each node.
+func PostOrderVisit[E any](node Node[E], v func(visit Visit[E]) bool) bool {
+	s := &visitor[E]{v: v}
+	return s.postOrder(node, 0, RootSide, nil)
+}
+
+func (s *visitor[E]) postOrder(n Node[E], depth int, side Side, parent Node[E]) bool {
+	if left, ok := n.Left(); ok {
+		if !s.postOrder(left, depth+1, LeftSide, n) {
+			return false
+		}
+	}
+
+	if right, ok := n.Right(); ok {
+		if !s.postOrder(right, depth+1, RightSide, n) {
+			return false
+		}
+	}
+
+	return s.visit(n, depth, side, parent)
+}
+
+// BreadthFirstVisit traverses a binary tree with breadth first ordering, passing the position of each node.
+// A Visit with an Index of zero starts a new level.
+func BreadthFirstVisit[E any](node Node[E], v func(visit Visit[E]) bool) bool {
+	return Levels(node, func(level []Visit[E]) bool {
+		for _, visit := range level {
+			if !v(visit) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Levels traverses a binary tree one level at a time, starting at the root.  v is passed every node
+// of a level together.
+func Levels[E any](node Node[E], v func(level []Visit[E]) bool) bool {
+	level := []Visit[E]{{Node: node}}
+	for len(level) > 0 {
+		if !v(level) {
+			return false
+		}
+
+		children := make([]Visit[E], 0, len(level)*2)
+		for _, parent := range level {
+			depth := parent.Depth + 1
+			if left, ok := parent.Node.Left(); ok {
+				children = append(children, Visit[E]{Node: left, Depth: depth, Index: len(children), Side: LeftSide, Parent: parent.Node})
+			}
+			if right, ok := parent.Node.Right(); ok {
+				children = append(children, Visit[E]{Node: right, Depth: depth, Index: len(children), Side: RightSide, Parent: parent.Node})
+			}
+		}
+		level = children
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+package canopy
+
+import (
+	"fmt"
+	"testing"
+)
+
+// describe a visit as value:depth:index:side:parent
+func describeVisit(v Visit[int]) string {
+	parent := "-"
+	if v.Parent != nil {
+		parent = fmt.Sprint(v.Parent.Value())
+	}
+	return fmt.Sprintf("%d:%d:%d:%s:%s", v.Node.Value(), v.Depth, v.Index, v.Side, parent)
+}
+
+func TestVisitTraversals(t *testing.T) {
+	type visitFunc func(node Node[int], v func(visit Visit[int]) bool) bool
+	funcs := map[string]visitFunc{
+		"pre":     PreOrderVisit[int],
+		"in":      InOrderVisit[int],
+		"post":    PostOrderVisit[int],
+		"breadth": BreadthFirstVisit[int],
+	}
+
+	//        100
+	//     20      200
+	//       30  150   300
+	expected := map[string][]string{
+		"pre":     {"100:0:0:root:-", "20:1:0:left:100", "30:2:0:right:20", "200:1:1:right:100", "150:2:1:left:200", "300:2:2:right:200"},
+		"in":      {"20:1:0:left:100", "30:2:0:right:20", "100:0:0:root:-", "150:2:1:left:200", "200:1:1:right:100", "300:2:2:right:200"},
+		"post":    {"30:2:0:right:20", "20:1:0:left:100", "150:2:1:left:200", "300:2:2:right:200", "200:1:1:right:100", "100:0:0:root:-"},
+		"breadth": {"100:0:0:root:-", "20:1:0:left:100", "200:1:1:right:100", "30:2:0:right:20", "150:2:1:left:200", "300:2:2:right:200"},
+	}
+
+	tree := NewBinarySearchTree[int]()
+	InsertAll(tree, 100, 20, 200, 30, 150, 300)
+
+	for name, f := range funcs {
+		actual := make([]string, 0)
+		TraverseVisit[int](tree, f, func(v Visit[int]) bool {
+			actual = append(actual, describeVisit(v))
+			return true
+		})
+		arrayEquals(t, name, expected[name], actual)
+
+		actual = actual[:0]
+		TraverseVisit[int](tree, f, func(v Visit[int]) bool {
+			actual = append(actual, describeVisit(v))
+			return len(actual) < 2
+		})
+		arrayEquals(t, name+" stop", expected[name][:2], actual)
+	}
+}
+
+func TestLevels(t *testing.T) {
+	tree := NewRedBlackTreeFromSorted([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+
+	sizes := make([]int, 0)
+	tree.Traverse(func(root Node[int], _ func(node Node[int]) bool) bool {
+		return Levels(root, func(level []Visit[int]) bool {
+			for i, v := range level {
+				if v.Index != i || v.Depth != len(sizes) {
+					t.Error("bad position for", v.Node.Value(), v.Depth, v.Index)
+				}
+			}
+			sizes = append(sizes, len(level))
+			return true
+		})
+	}, nil)
+	arrayEquals(t, "", []int{1, 2, 4, 3}, sizes)
+}